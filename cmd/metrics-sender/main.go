@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io/fs"
@@ -10,14 +11,18 @@ import (
 	"os/signal"
 	"path"
 	"sort"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/max-bytes/metrics-sender/pkg/buffer"
 	"github.com/max-bytes/metrics-sender/pkg/config"
-	"github.com/max-bytes/metrics-sender/pkg/influx"
+	"github.com/max-bytes/metrics-sender/pkg/output"
 	"github.com/max-bytes/metrics-sender/pkg/parser"
+	"github.com/max-bytes/metrics-sender/pkg/spool"
+	"github.com/max-bytes/metrics-sender/pkg/watcher"
 
-	client "github.com/influxdata/influxdb1-client/v2"
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
 	"github.com/remeh/sizedwaitgroup"
 	"github.com/sirupsen/logrus"
 )
@@ -73,70 +78,304 @@ func main() {
 		cancel()
 	}()
 
-	run(ctx, cfg, log)
+	err = run(ctx, cfg, log)
+	failOnError(err, "Error processing source folder", log)
+}
+
+// source bundles the state that is needed to process the files of a source folder
+type source struct {
+	parser     *parser.Parser
+	spool      *spool.Spool
+	stability  *spool.StabilityChecker
+	quarantine *spool.Quarantine // nil if no error folder is configured
+	outputs    *output.Fanout
+	// workers limits the files of the source that are processed at the same time to maxConcurrentWorkers,
+	// however they were found (watcher, rescan or retry)
+	workers *sizedwaitgroup.SizedWaitGroup
 }
 
 func run(ctx context.Context, cfg *config.Configuration, log *logrus.Logger) error {
+	var quarantine *spool.Quarantine
+	if cfg.ErrorFolder != "" {
+		var err error
+		quarantine, err = spool.NewQuarantine(cfg.ErrorFolder, cfg.ErrorFolderMaxFiles, cfg.ErrorFolderMaxAgeHours*time.Hour)
+		if err != nil {
+			return err
+		}
+	}
+
+	outputs, err := openOutputs(ctx, cfg, log)
+	if err != nil {
+		return err
+	}
+	defer outputs.Close()
+
+	// the parts of a source that are shared by all sources
+	shared := source{quarantine: quarantine, outputs: outputs}
+	sources := make([]*source, 0, len(cfg.Sources))
+	for _, sourceCfg := range cfg.Sources {
+		src, err := openSource(sourceCfg, cfg, shared, log)
+		if err != nil {
+			return err
+		}
+		defer src.spool.Close()
+		sources = append(sources, src)
+	}
+
+	// each source folder is processed independently
+	errs := make(chan error, len(sources))
+	for _, src := range sources {
+		go func(src *source) {
+			errs <- runSource(ctx, cfg, src, log)
+		}(src)
+	}
+	for range sources {
+		if err := <-errs; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openOutputs creates the outputs and their buffers, and starts their health checks and buffer drains
+// the outputs are shared by all sources and kept for the lifetime of the process
+func openOutputs(ctx context.Context, cfg *config.Configuration, log *logrus.Logger) (*output.Fanout, error) {
+	outputs := make([]*output.Output, 0, len(cfg.Outputs))
+	for _, outputCfg := range cfg.Outputs {
+		if tls, ok := outputCfg.TLS(); ok && tls.InsecureSkipVerify {
+			log.Warnf("TLS certificate verification of output %s is disabled, connections are vulnerable to man-in-the-middle attacks", outputCfg.Name)
+		}
+		o, err := output.New(outputCfg)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, o)
+
+		// optional outputs are not buffered, because they must not block anything
+		if cfg.Buffer.Folder != "" && o.Required {
+			o.Buffer, err = buffer.Open(path.Join(cfg.Buffer.Folder, o.Name), cfg.Buffer.MaxSizeMegabytes*1024*1024, cfg.Buffer.MaxAgeHours*time.Hour, log)
+			if err != nil {
+				return nil, err
+			}
+			go drainBuffer(ctx, cfg, o, log)
+		}
+
+		err = o.Ping()
+		if err != nil {
+			log.Warnf("Output %s is not available: %v", o.Name, err)
+		} else {
+			log.Infof("Output %s is available", o.Name)
+		}
+		if o.HealthCheckInterval > 0 {
+			go checkHealth(ctx, o, err == nil, log)
+		}
+	}
+	return output.NewFanout(outputs, cfg.Routes, log)
+}
+
+func openSource(sourceCfg config.ConfigurationSource, cfg *config.Configuration, shared source, log *logrus.Logger) (*source, error) {
+	p, err := parser.New(sourceCfg.Format, cfg.Template, cfg.Encoder, cfg.ParseMode == config.ParseModeLenient, log)
+	if err != nil {
+		return nil, err
+	}
+	stability, err := spool.NewStabilityChecker(cfg.Stability)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := spool.Open(sourceCfg.Folder, log)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Processing source folder %s in format %s", sourceCfg.Folder, sourceCfg.Format)
+	workers := sizedwaitgroup.New(cfg.MaxConcurrentWorkers)
+	src := shared
+	src.parser = p
+	src.spool = sp
+	src.stability = stability
+	src.workers = &workers
+	return &src, nil
+}
+
+func runSource(ctx context.Context, cfg *config.Configuration, src *source, log *logrus.Logger) error {
+	if cfg.WatchMode == config.WatchModeInotify {
+		return runWatch(ctx, cfg, src, log)
+	}
 
-	process(cfg, cfg.RereadFolderSeconds*time.Second, log) // initial processing, because first tick only happens after interval
+	process(cfg, src, cfg.RereadFolderSeconds*time.Second, log) // initial processing, because first tick only happens after interval
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-time.Tick(cfg.ProcessIntervalSeconds * time.Second):
-			process(cfg, cfg.RereadFolderSeconds*time.Second, log)
+			process(cfg, src, cfg.RereadFolderSeconds*time.Second, log)
+		}
+	}
+}
+
+// runWatch processes files as soon as the watcher reports them as written
+// the source folder is still fully rescanned periodically, to pick up files whose events were missed
+// files whose processing failed are not reported again, they are retried every processIntervalSeconds
+func runWatch(ctx context.Context, cfg *config.Configuration, src *source, log *logrus.Logger) error {
+	w, err := watcher.New(src.spool.Folder())
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	defer src.workers.Wait()
+
+	log.Infof("Watching source folder %s for new files", src.spool.Folder())
+
+	// initial processing, because files written before the watch was started are not reported
+	process(cfg, src, cfg.RereadFolderSeconds*time.Second, log)
+
+	// a rescanIntervalSeconds of 0 disables the periodic rescan, receiving from the nil channel blocks forever
+	var rescan <-chan time.Time
+	if cfg.RescanIntervalSeconds > 0 {
+		rescanTicker := time.NewTicker(cfg.RescanIntervalSeconds * time.Second)
+		defer rescanTicker.Stop()
+		rescan = rescanTicker.C
+	}
+	retryTicker := time.NewTicker(cfg.ProcessIntervalSeconds * time.Second)
+	defer retryTicker.Stop()
+
+	// files that were not stable when reported are checked again after a delay
+	recheck := make(chan string)
+	dispatch := func(name string) {
+		info, err := os.Stat(path.Join(src.spool.Folder(), name))
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				log.Errorf("Could not get info of file %s: %v", name, err)
+			}
+			src.stability.Forget(name)
+			return
+		}
+		if stable, retryAfter := src.stability.Check(name, info, time.Now()); !stable {
+			log.Tracef("File %s is not stable yet, checking again in %v", name, retryAfter)
+			time.AfterFunc(retryAfter, func() {
+				select {
+				case recheck <- name:
+				case <-ctx.Done():
+				}
+			})
+			return
+		}
+		startWorker(name, cfg, src, nil, log)
+	}
+	// files that are still claimed by this instance failed before, and are processed again without stability checks
+	retry := func() {
+		claimed, err := src.spool.Claimed()
+		if err != nil {
+			log.Errorf("Could not read claimed files: %v", err)
+			return
+		}
+		for _, entry := range claimed {
+			if entry.IsDir() || src.stability.Ignored(entry.Name()) {
+				continue
+			}
+			startWorker(entry.Name(), cfg, src, nil, log)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case name, ok := <-w.Events():
+			if !ok {
+				return fmt.Errorf("Watcher of source folder %s stopped", src.spool.Folder())
+			}
+			if src.stability.Ignored(name) {
+				continue
+			}
+			dispatch(name)
+		case name := <-recheck:
+			dispatch(name)
+		case err, ok := <-w.Errors():
+			if !ok {
+				return fmt.Errorf("Watcher of source folder %s stopped", src.spool.Folder())
+			}
+			if errors.Is(err, watcher.ErrRemoved) {
+				// the watch is gone, rescanning would hide that no new files are reported anymore
+				return fmt.Errorf("Stopped watching source folder %s: %v", src.spool.Folder(), err)
+			}
+			log.Warnf("Error watching source folder, rescanning: %v", err)
+			process(cfg, src, cfg.RereadFolderSeconds*time.Second, log)
+		case <-rescan:
+			log.Trace("Periodic rescan of source folder")
+			process(cfg, src, cfg.RereadFolderSeconds*time.Second, log)
+		case <-retryTicker.C:
+			retry()
 		}
 	}
 }
 
-func process(cfg *config.Configuration, rereadFolderInterval time.Duration, log *logrus.Logger) {
+func process(cfg *config.Configuration, src *source, rereadFolderInterval time.Duration, log *logrus.Logger) {
 
 	// process until done
 	for done := false; !done; {
-		done = processWithTimeout(cfg, rereadFolderInterval, log)
+		done = processWithTimeout(cfg, src, rereadFolderInterval, log)
 	}
 }
 
-func processWithTimeout(cfg *config.Configuration, timeout time.Duration, log *logrus.Logger) bool {
-	// read files in specified source folder, sort them by modification time so newer files are processed first
-	files, err := os.ReadDir(cfg.SourceFolder)
+func processWithTimeout(cfg *config.Configuration, src *source, timeout time.Duration, log *logrus.Logger) bool {
+	// files that are still claimed by this instance failed before and are retried
+	claimed, err := src.spool.Claimed()
 	if err != nil {
-		log.Errorf("Could not read source folder: %v", err)
+		log.Errorf("Could not read claimed files: %v", err)
 		return true
 	}
-	if len(files) <= 0 {
-		log.Info("No files to process")
+
+	// read files in specified source folder, sort them by modification time so newer files are processed first
+	entries, err := os.ReadDir(src.spool.Folder())
+	if err != nil {
+		log.Errorf("Could not read source folder %s: %v", src.spool.Folder(), err)
 		return true
 	}
 
-	// get modification times
-	modTimes := make([]time.Time, len(files))
-	for i := range modTimes {
-		ii, err := files[i].Info()
+	// get file infos, skipping files that are not completely written yet
+	files := make([]fs.FileInfo, 0, len(claimed)+len(entries))
+	seen := make([]string, 0, len(entries))
+	now := time.Now()
+	for i, entry := range append(claimed, entries...) {
+		isClaimed := i < len(claimed)
+		if entry.IsDir() || src.stability.Ignored(entry.Name()) {
+			continue
+		}
+		if !isClaimed {
+			seen = append(seen, entry.Name())
+		}
+		info, err := entry.Info()
 		if err != nil {
-			log.Errorf("Could not read info of file %s: %v", files[i].Name(), err)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue // already processed in the meantime
+			}
+			log.Errorf("Could not read info of file %s: %v", entry.Name(), err)
 			return true
 		}
-		modTimes[i] = ii.ModTime()
+		if !isClaimed {
+			if stable, _ := src.stability.Check(entry.Name(), info, now); !stable {
+				log.Tracef("File %s is not stable yet, skipping", entry.Name())
+				continue
+			}
+		}
+		files = append(files, info)
+	}
+	src.stability.Retain(seen)
+	if len(files) <= 0 {
+		log.Infof("No files to process in source folder %s", src.spool.Folder())
+		return true
 	}
 
 	// sort files by modification time
 	// to make them process latest first
 	sort.Slice(files, func(i, j int) bool {
-		return modTimes[i].After(modTimes[j])
+		return files[i].ModTime().After(files[j].ModTime())
 	})
 
-	influxConnection, err := influx.CreateInfluxConnection(cfg.Influx)
-	if err != nil {
-		log.Errorf("Could not connect to influx: %v", err)
-		return true
-	}
-	defer influxConnection.Close()
-
 	startTime := time.Now()
 
-	swg := sizedwaitgroup.New(cfg.MaxConcurrentWorkers)
+	var wg sync.WaitGroup
 
 	log.Tracef("Starting processing of %d files", len(files))
 
@@ -150,14 +389,10 @@ func processWithTimeout(cfg *config.Configuration, timeout time.Duration, log *l
 			break
 		}
 
-		swg.Add() // blocks if maximum number of workers reached, until a worker is finished
-		go func(file fs.DirEntry) {
-			defer swg.Done()
-			processSingleFile(file, influxConnection, cfg, log)
-		}(file)
+		startWorker(file.Name(), cfg, src, &wg, log)
 	}
 
-	swg.Wait()
+	wg.Wait()
 
 	if !earlyReturn {
 		log.Tracef("Finished processing of %d files", len(files))
@@ -166,41 +401,169 @@ func processWithTimeout(cfg *config.Configuration, timeout time.Duration, log *l
 	return !earlyReturn
 }
 
-func processSingleFile(file fs.DirEntry, influxConnection client.Client, cfg *config.Configuration, log *logrus.Logger) {
-	fileInfo, err := file.Info()
-	if err != nil {
-		log.Errorf("Could not get info of file %s: %v", file.Name(), err)
+// startWorker processes the file in a new goroutine, wg is notified when it is done (if not nil)
+// it blocks while the maximum number of workers of the source is reached, until a worker is finished
+func startWorker(name string, cfg *config.Configuration, src *source, wg *sync.WaitGroup, log *logrus.Logger) {
+	src.workers.Add()
+	if wg != nil {
+		wg.Add(1)
+	}
+	go func() {
+		defer src.workers.Done()
+		if wg != nil {
+			defer wg.Done()
+		}
+		processSingleFile(name, cfg, src, log)
+	}()
+}
+
+// filesInFlight contains the paths of files that are currently being processed
+// it prevents a file from being processed twice, when it is both reported by the watcher and found during a rescan
+var filesInFlight = struct {
+	sync.Mutex
+	paths map[string]struct{}
+}{paths: map[string]struct{}{}}
+
+func processSingleFile(name string, cfg *config.Configuration, src *source, log *logrus.Logger) {
+	fullPath := path.Join(src.spool.Folder(), name)
+	filesInFlight.Lock()
+	_, inFlight := filesInFlight.paths[fullPath]
+	filesInFlight.paths[fullPath] = struct{}{}
+	filesInFlight.Unlock()
+	if inFlight {
+		log.Tracef("File %s is already being processed, skipping", name)
 		return
 	}
-	if !fileInfo.IsDir() {
-		fullPath := path.Join(cfg.SourceFolder, file.Name())
+	defer func() {
+		filesInFlight.Lock()
+		delete(filesInFlight.paths, fullPath)
+		filesInFlight.Unlock()
+	}()
 
-		lines, err := readLines(fullPath)
-		if err != nil {
-			log.Errorf("Could not read file %s: %v", file.Name(), err)
+	// claim the file, so that no other instance processes it at the same time
+	// if processing fails, the file stays claimed and is retried during the next run
+	claimedPath, err := src.spool.Claim(name)
+	src.stability.Forget(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// already processed in the meantime, or claimed by another instance
+			log.Tracef("File %s does not exist anymore, skipping", name)
 			return
 		}
+		log.Errorf("Could not claim file %s: %v", name, err)
+		return
+	}
 
-		pointsInFile, err := parser.Parse(lines)
-		if err != nil {
-			log.Errorf("Could not parse file %s: %v", file.Name(), err)
-			return
+	lines, err := readLines(claimedPath)
+	if err != nil {
+		log.Errorf("Could not read file %s: %v", name, err)
+		return
+	}
+
+	pointsInFile, parseErrors, err := src.parser.Parse(lines)
+	if err != nil {
+		log.Errorf("Could not parse file %s: %v", name, err)
+		quarantineFile(name, fmt.Errorf("Could not parse file: %v", err), src, log)
+		return
+	}
+	if len(parseErrors) > 0 {
+		log.Warnf("Skipped %d of %d lines of file %s because they could not be parsed", len(parseErrors), len(lines), name)
+		for _, parseError := range parseErrors {
+			log.Debugf("Skipped invalid line of file %s: %v", name, parseError)
 		}
+	}
 
-		err = influx.Send(pointsInFile, influxConnection, cfg.Influx)
-		if err != nil {
-			log.Errorf("Could not send points of file %s to influx: %v", file.Name(), err)
+	err = src.outputs.Send(fullPath, pointsInFile)
+	if err != nil {
+		log.Errorf("Could not send points of file %s: %v", name, err)
+		if output.IsPermanent(err) {
+			// the outputs rejected the points, sending them again would fail again
+			quarantineFile(name, fmt.Errorf("Outputs rejected points: %v", err), src, log)
+		} else if attempts := src.spool.Failed(name); cfg.MaxSendAttempts > 0 && attempts >= cfg.MaxSendAttempts {
+			quarantineFile(name, fmt.Errorf("Could not send points after %d attempts: %v", attempts, err), src, log)
+		}
+		return
+	}
+
+	err = src.spool.Complete(name)
+	if err != nil {
+		log.Errorf("Could not delete file %s: %v", name, err)
+		return
+	}
+
+	log.Tracef("Successfully processed and sent metrics of file %s", name)
+}
+
+// drainBuffer sends the buffered points of the output every drainIntervalSeconds, as long as the output is available
+func drainBuffer(ctx context.Context, cfg *config.Configuration, o *output.Output, log *logrus.Logger) {
+	ticker := time.NewTicker(cfg.Buffer.DrainIntervalSeconds * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
 		}
 
-		err = os.Remove(fullPath)
-		if err != nil {
-			log.Errorf("Could not delete file %s: %v", file.Name(), err)
+		if err := o.Ping(); err != nil {
+			log.Debugf("Output %s is not available, not draining buffer: %v", o.Name, err)
+			continue
+		}
+		drain(o, log)
+	}
+}
+
+// drain sends the buffered points of the output, points the output rejected are dropped
+// the buffer is kept as it is if sending fails for other reasons
+func drain(o *output.Output, log *logrus.Logger) {
+	err := o.Buffer.Drain(func(points []*influxdb1.Point) error {
+		err := o.Send(points)
+		if output.IsPermanent(err) {
+			// would block the buffer forever
+			log.Errorf("Dropped %d buffered points, because output %s rejected them: %v", len(output.FailedPoints(err, points)), o.Name, err)
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		log.Errorf("Could not drain buffer of output %s: %v", o.Name, err)
+	}
+}
+
+// checkHealth pings the output every healthCheckIntervalSeconds and logs when its availability changes
+func checkHealth(ctx context.Context, o *output.Output, available bool, log *logrus.Logger) {
+	ticker := time.NewTicker(o.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
+		}
+
+		err := o.Ping()
+		if err != nil && available {
+			log.Warnf("Output %s is not available: %v", o.Name, err)
+		} else if err == nil && !available {
+			log.Infof("Output %s is available again", o.Name)
 		}
+		available = err == nil
+	}
+}
 
-		log.Tracef("Successfully processed and sent metrics of file %s", file.Name())
+// quarantineFile moves a claimed file that cannot be processed into the error folder
+// if no error folder is configured, the file stays claimed and is retried during the next run
+func quarantineFile(name string, reason error, src *source, log *logrus.Logger) {
+	if src.quarantine == nil {
+		return
+	}
+	err := src.spool.Quarantine(name, src.quarantine, reason)
+	if err != nil {
+		log.Errorf("Could not quarantine file %s: %v", name, err)
+		return
 	}
+	src.outputs.Forget(path.Join(src.spool.Folder(), name))
+	log.Warnf("Moved file %s into error folder", name)
 }
 
 func readLines(path string) ([]string, error) {
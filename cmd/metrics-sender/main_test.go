@@ -1,10 +1,22 @@
 package main
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"testing"
+	"time"
 
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/buffer"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/spool"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,3 +51,145 @@ func TestReadLongLines(t *testing.T) {
 }
 
 const reallyLongLine = "timestamp::1623407330!**!*!**!host::host234!**!*!**!service::CI-Alive!**!*!**!state::0!**!*!**!perfdata::rta=0.044000ms;3000.000000;5000.000000;0.000000 pl=0%;80;100;0!**!*!**!ciid::H234!**!*!**!ciname::host234!**!*!**!monitoringprofile::profile2!**!*!**!customer::INTERN!**!*!**!output::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 msoutput::PING OK - Packet loss = 0%, RTA = 0.04 ms"
+
+func TestUnauthorized(t *testing.T) {
+	// e.g. an expired token, which must not cause data to be dropped
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	sourceFolder := filepath.Join(dir, "spool")
+	errorFolder := filepath.Join(dir, "errors")
+	assert.Nil(t, os.Mkdir(sourceFolder, 0755))
+	configFile := filepath.Join(dir, "config.yml")
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: `+sourceFolder+`
+errorFolder: `+errorFolder+`
+influx:
+  url: "`+server.URL+`"
+  database: naemon
+  retry: {maxAttempts: 1}
+  healthCheckIntervalSeconds: 0
+`), 0644))
+	cfg, err := config.LoadConfig(configFile)
+	assert.Nil(t, err)
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	outputs, err := openOutputs(ctx, cfg, log)
+	assert.Nil(t, err)
+	defer outputs.Close()
+	src, err := openSource(cfg.Sources[0], cfg, source{outputs: outputs}, log)
+	assert.Nil(t, err)
+	defer src.spool.Close()
+	src.quarantine, err = spool.NewQuarantine(errorFolder, 0, 0)
+	assert.Nil(t, err)
+
+	lines, err := os.ReadFile("../../testfiles/hostperfdata")
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(filepath.Join(sourceFolder, "perfdata"), lines, 0644))
+
+	// the file stays claimed and is retried
+	processSingleFile("perfdata", cfg, src, log)
+	claimed, err := src.spool.Claimed()
+	assert.Nil(t, err)
+	assert.Len(t, claimed, 1)
+	quarantined, err := os.ReadDir(errorFolder)
+	assert.Nil(t, err)
+	assert.Len(t, quarantined, 0)
+
+	// the buffer is kept as it is
+	o := outputs.Outputs()[0]
+	o.Buffer, err = buffer.Open(filepath.Join(dir, "buffer"), 0, 0, log)
+	assert.Nil(t, err)
+	point, err := influxdb1.NewPoint("state", map[string]string{"host": "host123"}, map[string]interface{}{"value": 0}, time.Unix(1623407324, 0))
+	assert.Nil(t, err)
+	assert.Nil(t, o.Buffer.Append([]*influxdb1.Point{point}))
+	segments, err := os.ReadDir(filepath.Join(dir, "buffer"))
+	assert.Nil(t, err)
+	assert.Len(t, segments, 1)
+
+	drain(o, log)
+	drained, err := os.ReadDir(filepath.Join(dir, "buffer"))
+	assert.Nil(t, err)
+	assert.Equal(t, segments, drained)
+}
+
+func TestWatchRetriesClaimedFiles(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("watching directories is only supported on linux")
+	}
+
+	// the first write fails, e.g. because influx is restarting
+	var mutex sync.Mutex
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	sourceFolder := filepath.Join(dir, "spool")
+	assert.Nil(t, os.Mkdir(sourceFolder, 0755))
+	configFile := filepath.Join(dir, "config.yml")
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: `+sourceFolder+`
+watchMode: inotify
+rescanIntervalSeconds: 0
+processIntervalSeconds: 1
+influx:
+  url: "`+server.URL+`"
+  database: naemon
+  retry: {maxAttempts: 1}
+  healthCheckIntervalSeconds: 0
+`), 0644))
+	cfg, err := config.LoadConfig(configFile)
+	assert.Nil(t, err)
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	ctx, cancel := context.WithCancel(context.Background())
+	outputs, err := openOutputs(ctx, cfg, log)
+	assert.Nil(t, err)
+	defer outputs.Close()
+	src, err := openSource(cfg.Sources[0], cfg, source{outputs: outputs}, log)
+	assert.Nil(t, err)
+	defer src.spool.Close()
+
+	lines, err := os.ReadFile("../../testfiles/hostperfdata")
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(filepath.Join(sourceFolder, "perfdata"), lines, 0644))
+
+	stopped := make(chan error)
+	go func() {
+		stopped <- runWatch(ctx, cfg, src, log)
+	}()
+
+	// the initial processing fails, the file is delivered by the retry although the rescan is disabled
+	assert.Eventually(t, func() bool {
+		claimed, err := src.spool.Claimed()
+		mutex.Lock()
+		defer mutex.Unlock()
+		return err == nil && len(claimed) == 0 && requests == 2
+	}, 5*time.Second, 50*time.Millisecond)
+	_, err = os.Stat(filepath.Join(sourceFolder, "perfdata"))
+	assert.True(t, os.IsNotExist(err))
+
+	cancel()
+	assert.Nil(t, <-stopped)
+}
@@ -0,0 +1,283 @@
+package buffer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb1-client/models"
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	segmentSuffix = ".lp.gz"
+	tempSuffix    = ".tmp"
+)
+
+// Buffer keeps points that could not be sent in segment files, until they can be sent again
+// each segment holds the points of one append as gzip compressed line protocol, sorted by timestamp;
+// segments are named after their oldest timestamp, so that they can be drained in timestamp order
+type Buffer struct {
+	folder   string
+	maxBytes int64
+	maxAge   time.Duration
+	log      logrus.FieldLogger
+
+	mutex    sync.Mutex
+	sequence int
+}
+
+// Open creates the buffer folder, if necessary, and removes segments that were not completely written
+// maxBytes and maxAge limit the total size of the segments and the time they are kept, 0 means unlimited
+// the folder must not be shared by several instances
+func Open(folder string, maxBytes int64, maxAge time.Duration, log logrus.FieldLogger) (*Buffer, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("Could not create buffer folder %s: %v", folder, err)
+	}
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read buffer folder %s: %v", folder, err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), tempSuffix) {
+			os.Remove(filepath.Join(folder, entry.Name()))
+		}
+	}
+	return &Buffer{folder: folder, maxBytes: maxBytes, maxAge: maxAge, log: log}, nil
+}
+
+// Append writes the points into a new segment
+func (b *Buffer) Append(points []*influxdb1.Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+	sorted := make([]*influxdb1.Point, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time().Before(sorted[j].Time())
+	})
+
+	var content bytes.Buffer
+	w := gzip.NewWriter(&content)
+	for _, point := range sorted {
+		if _, err := io.WriteString(w, point.String()+"\n"); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := time.Now()
+	oldest := sorted[0].Time().UnixNano()
+	if oldest < 0 {
+		oldest = 0
+	}
+	b.sequence++
+	name := fmt.Sprintf("%020d-%020d-%d%s", oldest, now.UnixNano(), b.sequence, segmentSuffix)
+	path := filepath.Join(b.folder, name)
+
+	// write into a temporary file first, so that a crash does not leave a partial segment behind
+	err := os.WriteFile(path+tempSuffix, content.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("Could not write buffer segment %s: %v", path, err)
+	}
+	err = os.Rename(path+tempSuffix, path)
+	if err != nil {
+		os.Remove(path + tempSuffix)
+		return fmt.Errorf("Could not write buffer segment %s: %v", path, err)
+	}
+
+	return b.prune(now)
+}
+
+// Drain sends the buffered points in timestamp order and removes the segments that were sent successfully
+// segments whose time ranges overlap are merged and sent together, other segments are sent one by one
+// it stops at the first error, the remaining segments are kept for the next call
+func (b *Buffer) Drain(send func(points []*influxdb1.Point) error) error {
+	b.mutex.Lock()
+	err := b.prune(time.Now())
+	var segments []string
+	if err == nil {
+		segments, err = b.segments()
+	}
+	b.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(segments); {
+		// the segments are sorted by their oldest timestamp, a segment overlaps the group if it starts before the group ends
+		var group []string
+		var sorted [][]*influxdb1.Point
+		var newest int64
+		for ; i < len(segments); i++ {
+			oldest, err := segmentOldest(segments[i])
+			if len(group) > 0 && (err != nil || oldest > newest) {
+				break
+			}
+			path := filepath.Join(b.folder, segments[i])
+			points, err := readSegment(path)
+			if errors.Is(err, fs.ErrNotExist) {
+				// removed by prune in the meantime
+				continue
+			}
+			if err != nil || len(points) == 0 {
+				// a corrupt segment would block the buffer forever
+				b.log.Errorf("Dropped unreadable buffer segment %s: %v", segments[i], err)
+				os.Remove(path)
+				continue
+			}
+			if last := points[len(points)-1].Time().UnixNano(); len(group) == 0 || last > newest {
+				newest = last
+			}
+			group = append(group, segments[i])
+			sorted = append(sorted, points)
+		}
+		if len(group) == 0 {
+			continue
+		}
+
+		points := merge(sorted)
+		err = send(points)
+		if err != nil {
+			return err
+		}
+		for _, segment := range group {
+			err = os.Remove(filepath.Join(b.folder, segment))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("Could not remove buffer segment %s: %v", segment, err)
+			}
+		}
+		b.log.Infof("Sent %d buffered points of segments %s", len(points), strings.Join(group, ", "))
+	}
+	return nil
+}
+
+// segmentOldest returns the oldest timestamp of a segment, which is the first part of its name
+func segmentOldest(name string) (int64, error) {
+	end := strings.IndexByte(name, '-')
+	if end < 0 {
+		return 0, fmt.Errorf("Invalid buffer segment name %s", name)
+	}
+	return strconv.ParseInt(name[:end], 10, 64)
+}
+
+// merge merges lists of points that are sorted by timestamp (k-way merge)
+// points with the same timestamp keep the order of their lists
+func merge(sorted [][]*influxdb1.Point) []*influxdb1.Point {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	total := 0
+	for _, points := range sorted {
+		total += len(points)
+	}
+	merged := make([]*influxdb1.Point, 0, total)
+	next := make([]int, len(sorted))
+	for len(merged) < total {
+		min := -1
+		for i, points := range sorted {
+			if next[i] < len(points) && (min < 0 || points[next[i]].Time().Before(sorted[min][next[min]].Time())) {
+				min = i
+			}
+		}
+		merged = append(merged, sorted[min][next[min]])
+		next[min]++
+	}
+	return merged
+}
+
+// segments returns the names of all segments, oldest timestamp first
+func (b *Buffer) segments() ([]string, error) {
+	entries, err := os.ReadDir(b.folder)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read buffer folder %s: %v", b.folder, err)
+	}
+	var segments []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), segmentSuffix) {
+			segments = append(segments, entry.Name())
+		}
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
+
+// prune removes segments that were buffered longer than maxAge, and the segments with the oldest timestamps
+// as long as the total size exceeds maxBytes
+func (b *Buffer) prune(now time.Time) error {
+	if b.maxBytes <= 0 && b.maxAge <= 0 {
+		return nil
+	}
+	segments, err := b.segments()
+	if err != nil {
+		return err
+	}
+
+	var infos []fs.FileInfo
+	var total int64
+	for _, segment := range segments {
+		info, err := os.Stat(filepath.Join(b.folder, segment))
+		if err != nil {
+			continue
+		}
+		infos = append(infos, info)
+		total += info.Size()
+	}
+
+	for _, info := range infos {
+		tooOld := b.maxAge > 0 && now.Sub(info.ModTime()) > b.maxAge
+		tooLarge := b.maxBytes > 0 && total > b.maxBytes
+		if !tooOld && !tooLarge {
+			continue
+		}
+		err = os.Remove(filepath.Join(b.folder, info.Name()))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("Could not remove buffer segment %s: %v", info.Name(), err)
+		}
+		total -= info.Size()
+		b.log.Warnf("Dropped buffer segment %s because the buffer exceeded its size or age limit", info.Name())
+	}
+	return nil
+}
+
+func readSegment(path string) ([]*influxdb1.Point, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	r, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := models.ParsePoints(content)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]*influxdb1.Point, 0, len(parsed))
+	for _, point := range parsed {
+		points = append(points, influxdb1.NewPointFrom(point))
+	}
+	return points, nil
+}
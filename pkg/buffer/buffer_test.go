@@ -0,0 +1,123 @@
+package buffer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPoint(t *testing.T, host string, timestamp int64) *influxdb1.Point {
+	point, err := influxdb1.NewPoint("state", map[string]string{"host": host}, map[string]interface{}{"value": 0}, time.Unix(timestamp, 0))
+	assert.Nil(t, err)
+	return point
+}
+
+func drained(t *testing.T, b *Buffer) []string {
+	var lines []string
+	err := b.Drain(func(points []*influxdb1.Point) error {
+		for _, point := range points {
+			lines = append(lines, point.String())
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return lines
+}
+
+func TestDrainInTimestampOrder(t *testing.T) {
+	folder := t.TempDir()
+	b, err := Open(folder, 0, 0, logrus.StandardLogger())
+	assert.Nil(t, err)
+
+	assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "b", 1623407400), testPoint(t, "a", 1623407300)}))
+	assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "c", 1623407200)}))
+
+	// segments survive a restart
+	b, err = Open(folder, 0, 0, logrus.StandardLogger())
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"state,host=c value=0i 1623407200000000000",
+		"state,host=a value=0i 1623407300000000000",
+		"state,host=b value=0i 1623407400000000000",
+	}, drained(t, b))
+	assert.Empty(t, drained(t, b))
+}
+
+func TestDrainOverlappingSegments(t *testing.T) {
+	b, err := Open(t.TempDir(), 0, 0, logrus.StandardLogger())
+	assert.Nil(t, err)
+
+	// a and b overlap and are merged, c starts after both ended
+	assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "a1", 100), testPoint(t, "a2", 400)}))
+	assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "b1", 200), testPoint(t, "b2", 500)}))
+	assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "c1", 600)}))
+
+	var batches [][]string
+	err = b.Drain(func(points []*influxdb1.Point) error {
+		var hosts []string
+		for _, point := range points {
+			hosts = append(hosts, point.Tags()["host"])
+		}
+		batches = append(batches, hosts)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"a1", "b1", "a2", "b2"}, {"c1"}}, batches)
+	assert.Empty(t, drained(t, b))
+}
+
+func TestDrainStopsOnError(t *testing.T) {
+	b, err := Open(t.TempDir(), 0, 0, logrus.StandardLogger())
+	assert.Nil(t, err)
+	assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "a", 1623407300)}))
+	assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "b", 1623407400)}))
+
+	calls := 0
+	err = b.Drain(func(points []*influxdb1.Point) error {
+		calls++
+		return errors.New("unavailable")
+	})
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, []string{
+		"state,host=a value=0i 1623407300000000000",
+		"state,host=b value=0i 1623407400000000000",
+	}, drained(t, b))
+}
+
+func TestLimits(t *testing.T) {
+	folder := t.TempDir()
+	b, err := Open(folder, 0, time.Hour, logrus.StandardLogger())
+	assert.Nil(t, err)
+	assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "a", 1623407300)}))
+	segments, err := b.segments()
+	assert.Nil(t, err)
+	old := time.Now().Add(-2 * time.Hour)
+	assert.Nil(t, os.Chtimes(filepath.Join(folder, segments[0]), old, old))
+	assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "b", 1623407400)}))
+	assert.Equal(t, []string{"state,host=b value=0i 1623407400000000000"}, drained(t, b))
+
+	// the segments with the oldest timestamps are dropped first
+	b.maxAge = 0
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "a", 1623407300+int64(i))}))
+	}
+	segments, err = b.segments()
+	assert.Nil(t, err)
+	info, err := os.Stat(filepath.Join(folder, segments[0]))
+	assert.Nil(t, err)
+	b.maxBytes = 2 * info.Size()
+	assert.Nil(t, b.Append([]*influxdb1.Point{testPoint(t, "a", 1623407310)}))
+	assert.Equal(t, []string{
+		"state,host=a value=0i 1623407302000000000",
+		"state,host=a value=0i 1623407310000000000",
+	}, drained(t, b))
+}
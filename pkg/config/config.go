@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
@@ -19,27 +21,527 @@ func LoadConfig(configFile string) (*Configuration, error) {
 		ProcessIntervalSeconds: 5,
 		RereadFolderSeconds:    180,
 		MaxConcurrentWorkers:   1,
+		WatchMode:              WatchModePoll,
+		ParseMode:              ParseModeStrict,
+		RescanIntervalSeconds:  60,
+		Template: ConfigurationTemplate{
+			Delimiter:    "!**!*!**!",
+			Separator:    "::",
+			TimestampKey: "timestamp",
+			StateKey:     "state",
+			PerfdataKey:  "perfdata",
+		},
+		Encoder: ConfigurationEncoder{
+			// the plugin output would create a new series for every distinct value as a tag
+			FieldKeys: []string{"output"},
+			Schema: ConfigurationSchema{
+				Layout:            LayoutNarrow,
+				MetricMeasurement: "metric",
+				StateMeasurement:  "state",
+			},
+		},
+		Influx: defaultInflux(),
+		Stability: ConfigurationStability{
+			SizeCheckIntervalSeconds: 1,
+		},
+		Buffer: ConfigurationBuffer{
+			MaxSizeMegabytes:     1024,
+			MaxAgeHours:          72,
+			DrainIntervalSeconds: 10,
+		},
 	}
 	decoder := yaml.NewDecoder(f)
 	err = decoder.Decode(&cfg)
 	if err != nil {
 		return nil, err
 	}
+	if cfg.WatchMode != WatchModePoll && cfg.WatchMode != WatchModeInotify {
+		return nil, fmt.Errorf("Invalid watchMode %s, must be one of %s, %s", cfg.WatchMode, WatchModePoll, WatchModeInotify)
+	}
+	if cfg.ParseMode != ParseModeStrict && cfg.ParseMode != ParseModeLenient {
+		return nil, fmt.Errorf("Invalid parseMode %s, must be one of %s, %s", cfg.ParseMode, ParseModeStrict, ParseModeLenient)
+	}
+	if cfg.ProcessIntervalSeconds <= 0 {
+		return nil, fmt.Errorf("Invalid processIntervalSeconds %d, must be greater than 0", cfg.ProcessIntervalSeconds)
+	}
+	if cfg.RescanIntervalSeconds < 0 {
+		return nil, fmt.Errorf("Invalid rescanIntervalSeconds %d, must not be negative", cfg.RescanIntervalSeconds)
+	}
+	if cfg.Buffer.DrainIntervalSeconds <= 0 {
+		return nil, fmt.Errorf("Invalid buffer.drainIntervalSeconds %d, must be greater than 0", cfg.Buffer.DrainIntervalSeconds)
+	}
+	if cfg.Template.Delimiter == "" || cfg.Template.Separator == "" {
+		return nil, fmt.Errorf("Template delimiter and separator must not be empty")
+	}
+
+	// the single influx section is the first output, which is required
+	if cfg.Influx.URL != "" {
+		cfg.Outputs = append([]ConfigurationOutput{{Name: OutputTypeInflux, Type: OutputTypeInflux, Required: true, Influx: cfg.Influx}}, cfg.Outputs...)
+	}
+	if len(cfg.Outputs) == 0 {
+		return nil, fmt.Errorf("No output configured, influx.url or outputs must be set")
+	}
+	outputNames := map[string]bool{}
+	for _, output := range cfg.Outputs {
+		if output.Name == "" {
+			return nil, fmt.Errorf("Output name must not be empty")
+		}
+		if outputNames[output.Name] {
+			return nil, fmt.Errorf("Duplicate output name %s", output.Name)
+		}
+		outputNames[output.Name] = true
+		if output.Type != OutputTypeInflux && output.Type != OutputTypePrometheus && output.Type != OutputTypeGraphite && output.Type != OutputTypeOTLP {
+			return nil, fmt.Errorf("Invalid type %s of output %s, must be one of %s, %s, %s, %s", output.Type, output.Name, OutputTypeInflux, OutputTypePrometheus, OutputTypeGraphite, OutputTypeOTLP)
+		}
+	}
+	// the outputs that convert points recognize state points by their measurement, which is only possible if it is not a template
+	stateMeasurement := cfg.Encoder.Schema.StateMeasurement
+	for i, output := range cfg.Outputs {
+		if output.Type != OutputTypeInflux && strings.Contains(stateMeasurement, "{{") {
+			return nil, fmt.Errorf("Output %s of type %s requires a constant encoder.schema.stateMeasurement, not the template %s", output.Name, output.Type, stateMeasurement)
+		}
+		cfg.Outputs[i].Prometheus.StateMeasurement = stateMeasurement
+		cfg.Outputs[i].Graphite.StateMeasurement = stateMeasurement
+		cfg.Outputs[i].OTLP.StateMeasurement = stateMeasurement
+	}
+	for _, route := range cfg.Routes {
+		for _, name := range route.Outputs {
+			if !outputNames[name] {
+				return nil, fmt.Errorf("Unknown output %s in route", name)
+			}
+		}
+	}
+
+	// the single sourceFolder is the first source, using the default format
+	if cfg.SourceFolder != "" {
+		cfg.Sources = append([]ConfigurationSource{{Folder: cfg.SourceFolder}}, cfg.Sources...)
+	}
+	if len(cfg.Sources) == 0 {
+		return nil, fmt.Errorf("No source folder configured, sourceFolder or sources must be set")
+	}
+	for i := range cfg.Sources {
+		if cfg.Sources[i].Format == "" {
+			cfg.Sources[i].Format = FormatTemplate
+		}
+		if cfg.Sources[i].Format != FormatTemplate && cfg.Sources[i].Format != FormatNagflux {
+			return nil, fmt.Errorf("Invalid format %s of source folder %s, must be one of %s, %s", cfg.Sources[i].Format, cfg.Sources[i].Folder, FormatTemplate, FormatNagflux)
+		}
+	}
 	return &cfg, nil
 }
 
+const (
+	// WatchModePoll reads the source folder every processIntervalSeconds
+	WatchModePoll = "poll"
+	// WatchModeInotify processes files as soon as they are written, with a full rescan every rescanIntervalSeconds
+	WatchModeInotify = "inotify"
+)
+
+const (
+	// ParseModeStrict rejects a whole file if one of its lines is invalid
+	ParseModeStrict = "strict"
+	// ParseModeLenient skips invalid lines and processes the valid ones
+	ParseModeLenient = "lenient"
+)
+
+const (
+	// FormatTemplate is the format described by the template section
+	FormatTemplate = "template"
+	// FormatNagflux is the tab separated format used by nagflux and PNP4Nagios (DATATYPE::SERVICEPERFDATA...)
+	FormatNagflux = "nagflux"
+)
+
+func defaultInflux() ConfigurationInflux {
+	return ConfigurationInflux{
+		Retry: ConfigurationRetry{
+			MaxAttempts:                3,
+			InitialBackoffMilliseconds: 500,
+			MaxBackoffSeconds:          30,
+			Multiplier:                 2,
+			Jitter:                     0.2,
+		},
+		Batch: ConfigurationBatch{
+			MaxPoints: 5000,
+			MaxBytes:  5 * 1024 * 1024,
+		},
+		HealthCheckIntervalSeconds: 30,
+		Connection: ConfigurationConnection{
+			MaxConnections:        10,
+			IdleTimeoutSeconds:    90,
+			RequestTimeoutSeconds: 30,
+			HTTP2:                 true,
+		},
+	}
+}
+
+func defaultPrometheus() ConfigurationPrometheus {
+	influx := defaultInflux()
+	return ConfigurationPrometheus{
+		MetricPrefix:               "naemon",
+		Retry:                      influx.Retry,
+		Batch:                      influx.Batch,
+		HealthCheckIntervalSeconds: influx.HealthCheckIntervalSeconds,
+		Connection:                 influx.Connection,
+	}
+}
+
+func defaultGraphite() ConfigurationGraphite {
+	influx := defaultInflux()
+	return ConfigurationGraphite{
+		Protocol:       GraphiteProtocolPlaintext,
+		Template:       "naemon.{customer}.{host}.{service}.{label}.{field}",
+		StateTemplate:  "naemon.{customer}.{host}.{service}.state",
+		TimeoutSeconds: 10,
+		Retry:          influx.Retry,
+		Batch: ConfigurationBatch{
+			MaxPoints: 1000,
+		},
+		HealthCheckIntervalSeconds: influx.HealthCheckIntervalSeconds,
+	}
+}
+
+func defaultOTLP() ConfigurationOTLP {
+	influx := defaultInflux()
+	return ConfigurationOTLP{
+		Encoding:                   OTLPEncodingProtobuf,
+		GZip:                       true,
+		MetricPrefix:               "naemon",
+		Retry:                      influx.Retry,
+		Batch:                      influx.Batch,
+		HealthCheckIntervalSeconds: influx.HealthCheckIntervalSeconds,
+		Connection:                 influx.Connection,
+	}
+}
+
+const (
+	// OutputTypeInflux writes to an influx compatible write API
+	OutputTypeInflux = "influx"
+	// OutputTypePrometheus writes to a prometheus remote write endpoint
+	OutputTypePrometheus = "prometheus"
+	// OutputTypeGraphite writes to a graphite/carbon receiver over TCP
+	OutputTypeGraphite = "graphite"
+	// OutputTypeOTLP writes to an OpenTelemetry OTLP/HTTP metrics endpoint
+	OutputTypeOTLP = "otlp"
+)
+
+const (
+	// GraphiteProtocolPlaintext sends one "<path> <value> <timestamp>" line per datapoint
+	GraphiteProtocolPlaintext = "plaintext"
+	// GraphiteProtocolPickle sends length prefixed pickled lists of datapoints
+	GraphiteProtocolPickle = "pickle"
+)
+
+const (
+	// OTLPEncodingProtobuf sends binary protobuf messages
+	OTLPEncodingProtobuf = "protobuf"
+	// OTLPEncodingJSON sends the JSON encoding of the protobuf messages
+	OTLPEncodingJSON = "json"
+)
+
+type ConfigurationOutput struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	// a file is only removed from the source folder once all required outputs accepted its points
+	Required   bool                    `yaml:"required"`
+	Influx     ConfigurationInflux     `yaml:"influx"`
+	Prometheus ConfigurationPrometheus `yaml:"prometheus"`
+	Graphite   ConfigurationGraphite   `yaml:"graphite"`
+	OTLP       ConfigurationOTLP       `yaml:"otlp"`
+}
+
+// UnmarshalYAML applies the defaults to each output, outputs are required and of type influx by default
+func (o *ConfigurationOutput) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain ConfigurationOutput
+	output := plain{Type: OutputTypeInflux, Required: true, Influx: defaultInflux(), Prometheus: defaultPrometheus(), Graphite: defaultGraphite(), OTLP: defaultOTLP()}
+	if err := unmarshal(&output); err != nil {
+		return err
+	}
+	// set afterwards, because yaml merges maps instead of replacing them
+	if output.OTLP.ResourceAttributes == nil {
+		output.OTLP.ResourceAttributes = map[string]string{"host": "host.name", "customer": "customer", "ciid": "ciid"}
+	}
+	*o = ConfigurationOutput(output)
+	return nil
+}
+
+// TLS returns the TLS settings of the output, false if the output does not use TLS (graphite connects over plain TCP)
+func (o ConfigurationOutput) TLS() (ConfigurationTLS, bool) {
+	switch o.Type {
+	case OutputTypeInflux:
+		return o.Influx.TLS, true
+	case OutputTypePrometheus:
+		return o.Prometheus.TLS, true
+	case OutputTypeOTLP:
+		return o.OTLP.TLS, true
+	}
+	return ConfigurationTLS{}, false
+}
+
 type ConfigurationInflux struct {
+	URL        string `yaml:"url"`
+	APIVersion int    `yaml:"apiVersion"`
+	Precision  string `yaml:"precision"`
+	GZip       bool   `yaml:"gzip"`
+	// v1
+	Database        string `yaml:"database"`
+	RetentionPolicy string `yaml:"retentionPolicy"`
+	Consistency     string `yaml:"consistency"`
+	// v2 and v3
+	Org    string `yaml:"org"`
+	Bucket string `yaml:"bucket"`
+	// authentication, at most one of these may be set
+	Token       Secret `yaml:"token"`
+	BearerToken Secret `yaml:"bearerToken"`
+	Username    string `yaml:"username"`
+	Password    Secret `yaml:"password"`
+	// additional headers that are sent with every request
+	Headers map[string]Secret  `yaml:"headers"`
+	TLS     ConfigurationTLS   `yaml:"tls"`
+	Retry   ConfigurationRetry `yaml:"retry"`
+	Batch   ConfigurationBatch `yaml:"batch"`
+	// the connection is probed with a ping at startup and in this interval, 0 disables the periodic probe
+	HealthCheckIntervalSeconds time.Duration           `yaml:"healthCheckIntervalSeconds"`
+	Connection                 ConfigurationConnection `yaml:"connection"`
+	// points are written to the target of the first matching route, or to the database/bucket above if none matches
+	Routes []ConfigurationInfluxRoute `yaml:"routes"`
+}
+
+type ConfigurationPrometheus struct {
+	// remote write url, e.g. http://localhost:9090/api/v1/write
+	URL string `yaml:"url"`
+	// metric names start with this prefix
+	MetricPrefix string `yaml:"metricPrefix"`
+	// points of this measurement become <prefix>_check_state, set to encoder.schema.stateMeasurement by LoadConfig
+	StateMeasurement string `yaml:"-"`
+	// authentication, at most one of these may be set
+	BearerToken Secret `yaml:"bearerToken"`
+	Username    string `yaml:"username"`
+	Password    Secret `yaml:"password"`
+	// additional headers that are sent with every request, e.g. X-Scope-OrgID
+	Headers                    map[string]Secret       `yaml:"headers"`
+	TLS                        ConfigurationTLS        `yaml:"tls"`
+	Retry                      ConfigurationRetry      `yaml:"retry"`
+	Batch                      ConfigurationBatch      `yaml:"batch"`
+	HealthCheckIntervalSeconds time.Duration           `yaml:"healthCheckIntervalSeconds"`
+	Connection                 ConfigurationConnection `yaml:"connection"`
+}
+
+type ConfigurationGraphite struct {
+	// host:port of the carbon receiver, usually port 2003 for plaintext and 2004 for pickle
+	Address  string `yaml:"address"`
+	Protocol string `yaml:"protocol"`
+	// path of the datapoints, placeholders like {host} are replaced by the tags of the point, {measurement} and {field} by its name and field
+	// placeholders without value become "unknown"; without {field}, only the field value is sent
+	Template string `yaml:"template"`
+	// path template of the points of stateMeasurement, which is set to encoder.schema.stateMeasurement by LoadConfig
+	StateTemplate    string `yaml:"stateTemplate"`
+	StateMeasurement string `yaml:"-"`
+	// timeout of connecting and writing
+	TimeoutSeconds             time.Duration      `yaml:"timeoutSeconds"`
+	Retry                      ConfigurationRetry `yaml:"retry"`
+	Batch                      ConfigurationBatch `yaml:"batch"`
+	HealthCheckIntervalSeconds time.Duration      `yaml:"healthCheckIntervalSeconds"`
+}
+
+type ConfigurationOTLP struct {
+	// metrics url, e.g. http://localhost:4318/v1/metrics
 	URL      string `yaml:"url"`
-	Database string `yaml:"database"`
+	Encoding string `yaml:"encoding"`
 	GZip     bool   `yaml:"gzip"`
+	// metric names start with this prefix
+	MetricPrefix string `yaml:"metricPrefix"`
+	// points of this measurement become the gauge <prefix>.check.state, set to encoder.schema.stateMeasurement by LoadConfig
+	StateMeasurement string `yaml:"-"`
+	// tag -> resource attribute, e.g. host: host.name; the other tags become attributes of the data points
+	ResourceAttributes map[string]string `yaml:"resourceAttributes"`
+	// authentication, at most one of these may be set
+	BearerToken Secret `yaml:"bearerToken"`
+	Username    string `yaml:"username"`
+	Password    Secret `yaml:"password"`
+	// additional headers that are sent with every request
+	Headers                    map[string]Secret       `yaml:"headers"`
+	TLS                        ConfigurationTLS        `yaml:"tls"`
+	Retry                      ConfigurationRetry      `yaml:"retry"`
+	Batch                      ConfigurationBatch      `yaml:"batch"`
+	HealthCheckIntervalSeconds time.Duration           `yaml:"healthCheckIntervalSeconds"`
+	Connection                 ConfigurationConnection `yaml:"connection"`
+}
+
+type ConfigurationInfluxRoute struct {
+	// tag key -> condition, all conditions must match
+	Match map[string]ConfigurationMatch `yaml:"match"`
+	// empty values are taken from the influx section
+	Database        string `yaml:"database"`
+	RetentionPolicy string `yaml:"retentionPolicy"`
+	Org             string `yaml:"org"`
+	Bucket          string `yaml:"bucket"`
+}
+
+// ConfigurationOutputRoute sends the points it matches only to the listed outputs,
+// points that match no route are sent to all outputs
+type ConfigurationOutputRoute struct {
+	// tag key -> condition, all conditions must match
+	Match   map[string]ConfigurationMatch `yaml:"match"`
+	Outputs []string                      `yaml:"outputs"`
+}
+
+// ConfigurationMatch is a condition on a tag value, exactly one of exact, glob and regex must be set
+// a plain string is an exact match:
+//
+//	customer: "acme"
+//	customer: {glob: "acme-*"}
+//	customer: {regex: "^(acme|globex)$"}
+type ConfigurationMatch struct {
+	Exact string `yaml:"exact"`
+	Glob  string `yaml:"glob"`
+	Regex string `yaml:"regex"`
+}
+
+func (m *ConfigurationMatch) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var exact string
+	if err := unmarshal(&exact); err == nil {
+		*m = ConfigurationMatch{Exact: exact}
+		return nil
+	}
+	type plain ConfigurationMatch
+	var match plain
+	if err := unmarshal(&match); err != nil {
+		return fmt.Errorf("Match must be a string or contain exact, glob or regex: %v", err)
+	}
+	*m = ConfigurationMatch(match)
+	return nil
+}
+
+type ConfigurationConnection struct {
+	// maximum number of (idle) connections per host, 0 means unlimited
+	MaxConnections        int           `yaml:"maxConnections"`
+	IdleTimeoutSeconds    time.Duration `yaml:"idleTimeoutSeconds"`
+	RequestTimeoutSeconds time.Duration `yaml:"requestTimeoutSeconds"`
+	// only relevant for https urls
+	HTTP2 bool `yaml:"http2"`
+}
+
+type ConfigurationBatch struct {
+	// 0 means no limit
+	MaxPoints int `yaml:"maxPoints"`
+	// size of the uncompressed line protocol for influx, of the payload as it is sent (after compression) for the other outputs,
+	// 0 means no limit
+	MaxBytes int `yaml:"maxBytes"`
+}
+
+type ConfigurationRetry struct {
+	// 1 means no retries
+	MaxAttempts                int           `yaml:"maxAttempts"`
+	InitialBackoffMilliseconds time.Duration `yaml:"initialBackoffMilliseconds"`
+	MaxBackoffSeconds          time.Duration `yaml:"maxBackoffSeconds"`
+	Multiplier                 float64       `yaml:"multiplier"`
+	// the backoff is randomly spread by +/- this fraction
+	Jitter float64 `yaml:"jitter"`
+}
+
+type ConfigurationTLS struct {
+	CAFile     string `yaml:"caFile"`
+	CertFile   string `yaml:"certFile"`
+	KeyFile    string `yaml:"keyFile"`
+	ServerName string `yaml:"serverName"`
+	MinVersion string `yaml:"minVersion"`
+	// disables the verification of server certificates, only meant for testing
+	InsecureSkipVerify bool `yaml:"insecureSkipVerify"`
+}
+
+type ConfigurationTemplate struct {
+	Delimiter    string `yaml:"delimiter"`
+	Separator    string `yaml:"separator"`
+	TimestampKey string `yaml:"timestampKey"`
+	StateKey     string `yaml:"stateKey"`
+	PerfdataKey  string `yaml:"perfdataKey"`
+}
+
+type ConfigurationEncoder struct {
+	UnitNormalization ConfigurationUnitNormalization `yaml:"unitNormalization"`
+	FieldKeys         []string                       `yaml:"fieldKeys"`
+	FieldMeasurement  string                         `yaml:"fieldMeasurement"`
+	Tags              ConfigurationTags              `yaml:"tags"`
+	Schema            ConfigurationSchema            `yaml:"schema"`
+}
+
+const (
+	// LayoutNarrow creates one point per perfdata label, with the label as tag
+	LayoutNarrow = "narrow"
+	// LayoutWide creates one point per check result, with one field per perfdata label
+	LayoutWide = "wide"
+)
+
+type ConfigurationSchema struct {
+	Layout            string `yaml:"layout"`
+	MetricMeasurement string `yaml:"metricMeasurement"`
+	StateMeasurement  string `yaml:"stateMeasurement"`
+}
+
+const (
+	// TagDefaultAllow turns keys that are neither allowed nor denied into tags
+	TagDefaultAllow = "allow"
+	// TagDefaultDeny drops keys that are neither allowed nor denied
+	TagDefaultDeny = "deny"
+)
+
+type ConfigurationTags struct {
+	Allow   []string          `yaml:"allow"`
+	Deny    []string          `yaml:"deny"`
+	Rename  map[string]string `yaml:"rename"`
+	Default string            `yaml:"default"`
+}
+
+type ConfigurationUnitNormalization struct {
+	Enabled bool                                 `yaml:"enabled"`
+	Rules   []ConfigurationUnitNormalizationRule `yaml:"rules"`
+}
+
+type ConfigurationUnitNormalizationRule struct {
+	Service string `yaml:"service"`
+	Enabled bool   `yaml:"enabled"`
+}
+
+type ConfigurationSource struct {
+	Folder string `yaml:"folder"`
+	Format string `yaml:"format"`
+}
+
+type ConfigurationStability struct {
+	MinAgeSeconds            time.Duration `yaml:"minAgeSeconds"`
+	CheckSize                bool          `yaml:"checkSize"`
+	SizeCheckIntervalSeconds time.Duration `yaml:"sizeCheckIntervalSeconds"`
+	IgnorePattern            string        `yaml:"ignorePattern"`
+}
+
+type ConfigurationBuffer struct {
+	// the buffer is disabled if no folder is set
+	Folder               string        `yaml:"folder"`
+	MaxSizeMegabytes     int64         `yaml:"maxSizeMegabytes"`
+	MaxAgeHours          time.Duration `yaml:"maxAgeHours"`
+	DrainIntervalSeconds time.Duration `yaml:"drainIntervalSeconds"`
 }
 
 type Configuration struct {
-	SourceFolder           string              `yaml:"sourceFolder"`
-	ProcessIntervalSeconds time.Duration       `yaml:"processIntervalSeconds"`
-	RereadFolderSeconds    time.Duration       `yaml:"rereadFolderSeconds"`
-	LogLevel               string              `yaml:"logLevel"`
-	LogFile                string              `yaml:"logFile"`
-	Influx                 ConfigurationInflux `yaml:"influx"`
-	MaxConcurrentWorkers   int                 `yaml:"maxConcurrentWorkers"`
+	SourceFolder           string                     `yaml:"sourceFolder"`
+	Sources                []ConfigurationSource      `yaml:"sources"`
+	ProcessIntervalSeconds time.Duration              `yaml:"processIntervalSeconds"`
+	RereadFolderSeconds    time.Duration              `yaml:"rereadFolderSeconds"`
+	LogLevel               string                     `yaml:"logLevel"`
+	LogFile                string                     `yaml:"logFile"`
+	Influx                 ConfigurationInflux        `yaml:"influx"`
+	Outputs                []ConfigurationOutput      `yaml:"outputs"`
+	Routes                 []ConfigurationOutputRoute `yaml:"routes"`
+	MaxConcurrentWorkers   int                        `yaml:"maxConcurrentWorkers"`
+	WatchMode              string                     `yaml:"watchMode"`
+	RescanIntervalSeconds  time.Duration              `yaml:"rescanIntervalSeconds"`
+	Stability              ConfigurationStability     `yaml:"stability"`
+	ErrorFolder            string                     `yaml:"errorFolder"`
+	ErrorFolderMaxFiles    int                        `yaml:"errorFolderMaxFiles"`
+	ErrorFolderMaxAgeHours time.Duration              `yaml:"errorFolderMaxAgeHours"`
+	MaxSendAttempts        int                        `yaml:"maxSendAttempts"`
+	Buffer                 ConfigurationBuffer        `yaml:"buffer"`
+	ParseMode              string                     `yaml:"parseMode"`
+	Template               ConfigurationTemplate      `yaml:"template"`
+	Encoder                ConfigurationEncoder       `yaml:"encoder"`
 }
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestSecret(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "password")
+	assert.Nil(t, os.WriteFile(secretFile, []byte("from-file\n"), 0600))
+	os.Setenv("METRICS_SENDER_TEST_SECRET", "from-env")
+	defer os.Unsetenv("METRICS_SENDER_TEST_SECRET")
+
+	var secrets struct {
+		Plain Secret `yaml:"plain"`
+		File  Secret `yaml:"file"`
+		Env   Secret `yaml:"env"`
+	}
+	err := yaml.Unmarshal([]byte(`
+plain: "plain"
+file: {file: "`+secretFile+`"}
+env: {env: "METRICS_SENDER_TEST_SECRET"}
+`), &secrets)
+	assert.Nil(t, err)
+	assert.Equal(t, Secret("plain"), secrets.Plain)
+	assert.Equal(t, Secret("from-file"), secrets.File)
+	assert.Equal(t, Secret("from-env"), secrets.Env)
+
+	var missing struct {
+		Env Secret `yaml:"env"`
+	}
+	err = yaml.Unmarshal([]byte(`env: {env: "METRICS_SENDER_TEST_SECRET_MISSING"}`), &missing)
+	assert.NotNil(t, err)
+}
+
+func TestOutputs(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yml")
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: /tmp/naemon
+influx:
+  url: "http://localhost:8086"
+  database: naemon
+outputs:
+  - name: central
+    influx:
+      url: "http://central:8086"
+      database: customers
+      retry: {maxAttempts: 5}
+  - name: mirror
+    required: false
+    influx:
+      url: "http://mirror:8086"
+  - name: mimir
+    type: prometheus
+    prometheus:
+      url: "http://mimir:9009/api/v1/push"
+  - name: carbon
+    type: graphite
+    graphite:
+      address: "carbon:2004"
+      protocol: pickle
+  - name: collector
+    type: otlp
+    otlp:
+      url: "http://collector:4318/v1/metrics"
+      resourceAttributes: {host: host.name}
+`), 0644))
+
+	cfg, err := LoadConfig(configFile)
+	assert.Nil(t, err)
+	assert.Len(t, cfg.Outputs, 6)
+	assert.Equal(t, "influx", cfg.Outputs[0].Name)
+	assert.Equal(t, "naemon", cfg.Outputs[0].Influx.Database)
+	assert.True(t, cfg.Outputs[0].Required)
+	assert.Equal(t, "central", cfg.Outputs[1].Name)
+	assert.Equal(t, OutputTypeInflux, cfg.Outputs[1].Type)
+	assert.True(t, cfg.Outputs[1].Required)
+	assert.Equal(t, 5, cfg.Outputs[1].Influx.Retry.MaxAttempts)
+	// defaults apply to each output
+	assert.Equal(t, 2.0, cfg.Outputs[1].Influx.Retry.Multiplier)
+	assert.Equal(t, 5000, cfg.Outputs[2].Influx.Batch.MaxPoints)
+	assert.False(t, cfg.Outputs[2].Required)
+	assert.Equal(t, OutputTypePrometheus, cfg.Outputs[3].Type)
+	// taken from the encoder schema
+	assert.Equal(t, "state", cfg.Outputs[3].Prometheus.StateMeasurement)
+	assert.Equal(t, "state", cfg.Outputs[4].Graphite.StateMeasurement)
+	assert.Equal(t, "state", cfg.Outputs[5].OTLP.StateMeasurement)
+	assert.Equal(t, "naemon", cfg.Outputs[3].Prometheus.MetricPrefix)
+	assert.Equal(t, 3, cfg.Outputs[3].Prometheus.Retry.MaxAttempts)
+	assert.Equal(t, OutputTypeGraphite, cfg.Outputs[4].Type)
+	assert.Equal(t, GraphiteProtocolPickle, cfg.Outputs[4].Graphite.Protocol)
+	assert.Equal(t, "naemon.{customer}.{host}.{service}.{label}.{field}", cfg.Outputs[4].Graphite.Template)
+	assert.Equal(t, OutputTypeOTLP, cfg.Outputs[5].Type)
+	assert.Equal(t, OTLPEncodingProtobuf, cfg.Outputs[5].OTLP.Encoding)
+	// configured resource attributes replace the default ones
+	assert.Equal(t, map[string]string{"host": "host.name"}, cfg.Outputs[5].OTLP.ResourceAttributes)
+	assert.Equal(t, map[string]string{"host": "host.name", "customer": "customer", "ciid": "ciid"}, cfg.Outputs[4].OTLP.ResourceAttributes)
+	_, ok := cfg.Outputs[3].TLS()
+	assert.True(t, ok)
+	_, ok = cfg.Outputs[4].TLS()
+	assert.False(t, ok)
+
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: /tmp/naemon
+outputs:
+  - name: central
+  - name: central
+`), 0644))
+	_, err = LoadConfig(configFile)
+	assert.EqualError(t, err, "Duplicate output name central")
+
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: /tmp/naemon
+encoder:
+  schema: {stateMeasurement: "{{.service}}_state"}
+outputs:
+  - name: mimir
+    type: prometheus
+    prometheus: {url: "http://mimir:9009/api/v1/push"}
+`), 0644))
+	_, err = LoadConfig(configFile)
+	assert.EqualError(t, err, "Output mimir of type prometheus requires a constant encoder.schema.stateMeasurement, not the template {{.service}}_state")
+}
+
+func TestRoutes(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yml")
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: /tmp/naemon
+influx:
+  url: "http://localhost:8086"
+  database: naemon
+  routes:
+    - match: {customer: "acme", host: {glob: "web*"}}
+      database: naemon_acme
+routes:
+  - match: {customer: {regex: "^acme"}}
+    outputs: [influx]
+`), 0644))
+
+	cfg, err := LoadConfig(configFile)
+	assert.Nil(t, err)
+	assert.Equal(t, []ConfigurationInfluxRoute{{
+		Match:    map[string]ConfigurationMatch{"customer": {Exact: "acme"}, "host": {Glob: "web*"}},
+		Database: "naemon_acme",
+	}}, cfg.Outputs[0].Influx.Routes)
+	assert.Equal(t, []ConfigurationOutputRoute{{
+		Match:   map[string]ConfigurationMatch{"customer": {Regex: "^acme"}},
+		Outputs: []string{"influx"},
+	}}, cfg.Routes)
+
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: /tmp/naemon
+influx:
+  url: "http://localhost:8086"
+routes:
+  - outputs: [central]
+`), 0644))
+	_, err = LoadConfig(configFile)
+	assert.EqualError(t, err, "Unknown output central in route")
+}
+
+func TestIntervals(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yml")
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: /tmp/naemon
+influx: {url: "http://localhost:8086"}
+rescanIntervalSeconds: -1
+`), 0644))
+	_, err := LoadConfig(configFile)
+	assert.EqualError(t, err, "Invalid rescanIntervalSeconds -1, must not be negative")
+
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: /tmp/naemon
+influx: {url: "http://localhost:8086"}
+buffer: {drainIntervalSeconds: 0}
+`), 0644))
+	_, err = LoadConfig(configFile)
+	assert.EqualError(t, err, "Invalid buffer.drainIntervalSeconds 0, must be greater than 0")
+
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: /tmp/naemon
+influx: {url: "http://localhost:8086"}
+processIntervalSeconds: 0
+`), 0644))
+	_, err = LoadConfig(configFile)
+	assert.EqualError(t, err, "Invalid processIntervalSeconds 0, must be greater than 0")
+}
+
+func TestFieldKeys(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yml")
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: /tmp/naemon
+influx: {url: "http://localhost:8086"}
+`), 0644))
+	cfg, err := LoadConfig(configFile)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"output"}, cfg.Encoder.FieldKeys)
+
+	assert.Nil(t, os.WriteFile(configFile, []byte(`
+sourceFolder: /tmp/naemon
+influx: {url: "http://localhost:8086"}
+encoder: {fieldKeys: []}
+`), 0644))
+	cfg, err = LoadConfig(configFile)
+	assert.Nil(t, err)
+	assert.Empty(t, cfg.Encoder.FieldKeys)
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Secret is a confidential value that is either set directly in the config file,
+// or read from a separate file or an environment variable, so that it does not have to be part of the config file:
+//
+//	password: "plain text"
+//	password: {file: "/etc/metrics-sender/influx-password"}
+//	password: {env: "INFLUX_PASSWORD"}
+type Secret string
+
+func (s *Secret) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var plain string
+	if err := unmarshal(&plain); err == nil {
+		*s = Secret(plain)
+		return nil
+	}
+
+	var source struct {
+		File string `yaml:"file"`
+		Env  string `yaml:"env"`
+	}
+	if err := unmarshal(&source); err != nil {
+		return fmt.Errorf("Secret must be a string or contain either file or env: %v", err)
+	}
+	switch {
+	case source.File != "" && source.Env != "":
+		return fmt.Errorf("Secret must contain either file or env, not both")
+	case source.File != "":
+		content, err := os.ReadFile(source.File)
+		if err != nil {
+			return fmt.Errorf("Could not read secret file: %v", err)
+		}
+		// files usually end with a newline, which is not part of the secret
+		*s = Secret(strings.TrimRight(string(content), "\r\n"))
+	case source.Env != "":
+		value, found := os.LookupEnv(source.Env)
+		if !found {
+			return fmt.Errorf("Environment variable %s of secret is not set", source.Env)
+		}
+		*s = Secret(value)
+	default:
+		return fmt.Errorf("Secret must contain either file or env")
+	}
+	return nil
+}
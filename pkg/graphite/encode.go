@@ -0,0 +1,62 @@
+package graphite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strconv"
+)
+
+// pickle opcodes of protocol 2, which is understood by carbon running on python 2 and 3
+const (
+	opProto     = 0x80
+	opEmptyList = ']'
+	opMark      = '('
+	opAppends   = 'e'
+	opUnicode   = 'X'
+	opInt       = 'J'
+	opLong      = 0x8a
+	opFloat     = 'G'
+	opTuple2    = 0x86
+	opStop      = '.'
+)
+
+// encodePlaintext creates one "<path> <value> <timestamp>" line per datapoint
+func encodePlaintext(datapoints []datapoint) []byte {
+	var b bytes.Buffer
+	for _, d := range datapoints {
+		b.WriteString(d.path)
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatFloat(d.value, 'f', -1, 64))
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatInt(d.timestamp, 10))
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// encodePickle creates the pickled list [(path, (timestamp, value)), ...], prefixed by its length as 4 byte big endian
+func encodePickle(datapoints []datapoint) []byte {
+	var b bytes.Buffer
+	b.Write([]byte{0, 0, 0, 0, opProto, 2, opEmptyList, opMark})
+	for _, d := range datapoints {
+		b.WriteByte(opUnicode)
+		binary.Write(&b, binary.LittleEndian, uint32(len(d.path)))
+		b.WriteString(d.path)
+		if d.timestamp >= math.MinInt32 && d.timestamp <= math.MaxInt32 {
+			b.WriteByte(opInt)
+			binary.Write(&b, binary.LittleEndian, int32(d.timestamp))
+		} else {
+			b.Write([]byte{opLong, 8})
+			binary.Write(&b, binary.LittleEndian, d.timestamp)
+		}
+		b.WriteByte(opFloat)
+		binary.Write(&b, binary.BigEndian, d.value)
+		b.Write([]byte{opTuple2, opTuple2})
+	}
+	b.Write([]byte{opAppends, opStop})
+
+	payload := b.Bytes()
+	binary.BigEndian.PutUint32(payload, uint32(len(payload)-4))
+	return payload
+}
@@ -0,0 +1,141 @@
+// Package graphite writes points to graphite/carbon receivers using the plaintext or pickle protocol.
+package graphite
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/sinkutil"
+)
+
+// Client keeps a TCP connection to a carbon receiver, which is reopened when it breaks
+type Client struct {
+	address   string
+	encode    func(datapoints []datapoint) []byte
+	converter converter
+	timeout   time.Duration
+	retry     config.ConfigurationRetry
+	batch     config.ConfigurationBatch
+
+	mutex sync.Mutex
+	conn  net.Conn
+}
+
+func NewClient(cfg config.ConfigurationGraphite) (*Client, error) {
+	if _, _, err := net.SplitHostPort(cfg.Address); err != nil {
+		return nil, fmt.Errorf("Invalid graphite address %s: %v", cfg.Address, err)
+	}
+	client := &Client{
+		address: cfg.Address,
+		timeout: cfg.TimeoutSeconds * time.Second,
+		retry:   cfg.Retry,
+		batch:   cfg.Batch,
+	}
+	switch cfg.Protocol {
+	case config.GraphiteProtocolPlaintext:
+		client.encode = encodePlaintext
+	case config.GraphiteProtocolPickle:
+		client.encode = encodePickle
+	default:
+		return nil, fmt.Errorf("Invalid graphite protocol %s, must be one of %s, %s", cfg.Protocol, config.GraphiteProtocolPlaintext, config.GraphiteProtocolPickle)
+	}
+
+	var err error
+	if client.converter.template, err = parseTemplate(cfg.Template); err != nil {
+		return nil, err
+	}
+	if client.converter.stateTemplate, err = parseTemplate(cfg.StateTemplate); err != nil {
+		return nil, err
+	}
+	client.converter.stateMeasurement = cfg.StateMeasurement
+	return client, nil
+}
+
+// Send converts the points into datapoints and writes them in the configured protocol
+// carbon does not acknowledge datapoints, so a batch that was written before the connection broke may be sent twice,
+// which is harmless because graphite keeps one value per path and timestamp
+func (c *Client) Send(points []*influxdb1.Point) error {
+	return sinkutil.SendBatches(points, c.batch, c.retry, func(points []*influxdb1.Point) ([]byte, error) {
+		datapoints := c.converter.convert(points)
+		if len(datapoints) == 0 {
+			return nil, nil
+		}
+		return c.encode(datapoints), nil
+	}, c.write)
+}
+
+func (c *Client) write(payload []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	conn, err := c.connection()
+	if err != nil {
+		return err
+	}
+	if c.timeout > 0 {
+		conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	}
+	if _, err := conn.Write(payload); err != nil {
+		conn.Close()
+		c.conn = nil
+		return fmt.Errorf("Could not write to graphite at %s: %v", c.address, err)
+	}
+	return nil
+}
+
+// connection returns the open connection, or opens a new one if there is none or the receiver closed it
+// the caller must hold the mutex
+func (c *Client) connection() (net.Conn, error) {
+	if c.conn != nil {
+		if !closed(c.conn) {
+			return c.conn, nil
+		}
+		c.conn.Close()
+		c.conn = nil
+	}
+	conn, err := net.DialTimeout("tcp", c.address, c.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("Could not connect to graphite at %s: %v", c.address, err)
+	}
+	c.conn = conn
+	return conn, nil
+}
+
+// closed detects connections that were closed by the receiver, which never sends any data,
+// so that the next write does not get lost in a half closed connection
+func closed(conn net.Conn) bool {
+	conn.SetReadDeadline(time.Now().Add(time.Millisecond))
+	defer conn.SetReadDeadline(time.Time{})
+	var b [1]byte
+	_, err := conn.Read(b[:])
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return false
+	}
+	// io.EOF or a reset connection
+	return err != nil
+}
+
+// Ping opens the connection, if it is not open already
+func (c *Client) Ping() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	_, err := c.connection()
+	return err
+}
+
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
@@ -0,0 +1,122 @@
+package graphite
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/sinkutil/sinktest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestReceiver accepts connections and sends the received lines, each connection is closed after closeAfter lines
+func newTestReceiver(t *testing.T, closeAfter int) (string, chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	lines := make(chan string, 100)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			scanner := bufio.NewScanner(conn)
+			for i := 0; (closeAfter == 0 || i < closeAfter) && scanner.Scan(); i++ {
+				lines <- scanner.Text()
+			}
+			conn.Close()
+		}
+	}()
+	return listener.Addr().String(), lines
+}
+
+func receive(lines chan string, count int) []string {
+	var received []string
+	for i := 0; i < count; i++ {
+		select {
+		case line := <-lines:
+			received = append(received, line)
+		case <-time.After(time.Second):
+			return received
+		}
+	}
+	return received
+}
+
+func testConfig(address string) config.ConfigurationGraphite {
+	return config.ConfigurationGraphite{
+		Address:          address,
+		Protocol:         config.GraphiteProtocolPlaintext,
+		Template:         "naemon.{customer}.{host}.{service}.{label}.{field}",
+		StateTemplate:    "naemon.{customer}.{host}.{service}.state",
+		StateMeasurement: "state",
+		TimeoutSeconds:   1,
+		Retry:            config.ConfigurationRetry{MaxAttempts: 2},
+	}
+}
+
+func TestSendPlaintext(t *testing.T) {
+	address, lines := newTestReceiver(t, 0)
+	client, err := NewClient(testConfig(address))
+	assert.Nil(t, err)
+	defer client.Close()
+
+	assert.Nil(t, client.Send(sinktest.Points(t)))
+	assert.Equal(t, []string{
+		"naemon.acme_corp.host1.disk_C_.C___used.value 12.5 1623407324",
+		"naemon.acme_corp.host1.disk_C_.C___used.warn 80 1623407324",
+		"naemon.acme_corp.host1.disk_C_.state 2 1623407324",
+		"naemon.unknown.host2.unknown.state 0 1623407384",
+	}, receive(lines, 4))
+}
+
+func TestReconnect(t *testing.T) {
+	// the receiver closes the connection after each line
+	address, lines := newTestReceiver(t, 1)
+	cfg := testConfig(address)
+	cfg.Template = "naemon.{host}.{label}.value"
+	client, err := NewClient(cfg)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	assert.Nil(t, client.Send(sinktest.Points(t)[0:1]))
+	assert.Equal(t, []string{"naemon.host1.C___used.value 12.5 1623407324"}, receive(lines, 1))
+	time.Sleep(10 * time.Millisecond)
+	assert.Nil(t, client.Send(sinktest.Points(t)[0:1]))
+	assert.Equal(t, []string{"naemon.host1.C___used.value 12.5 1623407324"}, receive(lines, 1))
+
+	// the receiver is gone
+	client.Close()
+	cfg.Address = "127.0.0.1:1"
+	client, err = NewClient(cfg)
+	assert.Nil(t, err)
+	assert.NotNil(t, client.Ping())
+	assert.NotNil(t, client.Send(sinktest.Points(t)))
+}
+
+func TestEncodePickle(t *testing.T) {
+	payload := encodePickle([]datapoint{{path: "naemon.host1.rta", value: 0.5, timestamp: 1623407324}, {path: "naemon.ü", value: -2, timestamp: 4102444800}})
+	// pickle.loads(payload[4:]) == [('naemon.host1.rta', (1623407324, 0.5)), ('naemon.ü', (4102444800, -2.0))]
+	assert.Equal(t, "\x00\x00\x00\x4e\x80\x02](X\x10\x00\x00\x00naemon.host1.rtaJ\xdc\x3a\xc3\x60G\x3f\xe0\x00\x00\x00\x00\x00\x00\x86\x86"+
+		"X\x09\x00\x00\x00naemon.\xc3\xbc\x8a\x08\x00\x57\x86\xf4\x00\x00\x00\x00G\xc0\x00\x00\x00\x00\x00\x00\x00\x86\x86e.", string(payload))
+}
+
+func TestTemplate(t *testing.T) {
+	template, err := parseTemplate("naemon.svc_{service}.{label}")
+	assert.Nil(t, err)
+	assert.False(t, template.hasField)
+	tags := map[string]string{"service": "ping 6", "label": "rta"}
+	lookup := func(key string) string { return tags[key] }
+	assert.Equal(t, "naemon.svc_ping_6.rta", template.render(lookup))
+	delete(tags, "service")
+	assert.Equal(t, "naemon.svc_unknown.rta", template.render(lookup))
+
+	for _, invalid := range []string{"naemon..{host}", "naemon.{host", "naemon.{}"} {
+		_, err = parseTemplate(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
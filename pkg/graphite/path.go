@@ -0,0 +1,144 @@
+package graphite
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/sinkutil"
+)
+
+type datapoint struct {
+	path      string
+	value     float64
+	timestamp int64 // seconds
+}
+
+// part is either a literal or a placeholder
+type part struct {
+	literal string
+	key     string
+}
+
+// pathTemplate is a template like naemon.{host}.{service}.{label}.{field}, split into its segments
+type pathTemplate struct {
+	segments [][]part
+	hasField bool
+}
+
+func parseTemplate(template string) (pathTemplate, error) {
+	var t pathTemplate
+	for _, segment := range strings.Split(template, ".") {
+		if segment == "" {
+			return t, fmt.Errorf("Invalid graphite template %s, segments must not be empty", template)
+		}
+		var parts []part
+		for segment != "" {
+			start := strings.IndexByte(segment, '{')
+			if start < 0 {
+				parts = append(parts, part{literal: segment})
+				break
+			}
+			end := strings.IndexByte(segment[start:], '}') + start
+			if end < start+2 {
+				return t, fmt.Errorf("Invalid graphite template %s, placeholders must look like {tag}", template)
+			}
+			if start > 0 {
+				parts = append(parts, part{literal: segment[:start]})
+			}
+			key := segment[start+1 : end]
+			parts = append(parts, part{key: key})
+			if key == "field" {
+				t.hasField = true
+			}
+			segment = segment[end+1:]
+		}
+		t.segments = append(t.segments, parts)
+	}
+	return t, nil
+}
+
+// missingValue replaces placeholders without value, so that the following segments keep their level
+const missingValue = "unknown"
+
+// render replaces the placeholders by their sanitized values
+func (t pathTemplate) render(lookup func(key string) string) string {
+	segments := make([]string, 0, len(t.segments))
+	for _, parts := range t.segments {
+		var segment strings.Builder
+		for _, p := range parts {
+			if p.key == "" {
+				segment.WriteString(p.literal)
+				continue
+			}
+			value := lookup(p.key)
+			if value == "" {
+				value = missingValue
+			}
+			segment.WriteString(sanitize(value))
+		}
+		segments = append(segments, segment.String())
+	}
+	return strings.Join(segments, ".")
+}
+
+// converter turns points into datapoints
+type converter struct {
+	template         pathTemplate
+	stateTemplate    pathTemplate
+	stateMeasurement string
+}
+
+// convert creates a datapoint for each numeric field of the points, or only for the field value if the template has no {field}
+func (c *converter) convert(points []*influxdb1.Point) []datapoint {
+	var datapoints []datapoint
+	for _, point := range points {
+		fields, err := point.Fields()
+		if err != nil {
+			continue
+		}
+		tags := point.Tags()
+		template := c.template
+		if point.Name() == c.stateMeasurement {
+			template = c.stateTemplate
+		}
+
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, field := range keys {
+			number, ok := sinkutil.ToFloat(fields[field])
+			if !ok || (!template.hasField && field != "value") {
+				continue
+			}
+			path := template.render(func(key string) string {
+				switch key {
+				case "measurement":
+					return point.Name()
+				case "field":
+					return field
+				}
+				return tags[key]
+			})
+			datapoints = append(datapoints, datapoint{path: path, value: number, timestamp: point.Time().Unix()})
+		}
+	}
+	return datapoints
+}
+
+// sanitize replaces the characters of a tag value that have a special meaning in graphite paths (e.g. dots and spaces),
+// only letters, digits, _ and - are kept
+func sanitize(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
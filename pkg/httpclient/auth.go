@@ -0,0 +1,53 @@
+package httpclient
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+)
+
+// Credentials are the ways to authenticate at a server, at most one of them or an Authorization header may be set
+type Credentials struct {
+	// Token is sent with the Token scheme of InfluxDB 2.x
+	Token       config.Secret
+	BearerToken config.Secret
+	Username    string
+	Password    config.Secret
+}
+
+// Headers returns the configured headers and the Authorization header of the credentials, which are sent with every request
+// backend is the kind of server for the error message, e.g. influx
+func Headers(backend string, configured map[string]config.Secret, credentials Credentials) (http.Header, error) {
+	headers := http.Header{}
+	for key, value := range configured {
+		headers.Set(key, string(value))
+	}
+
+	var set []string
+	if _, ok := headers["Authorization"]; ok {
+		set = append(set, "headers.Authorization")
+	}
+	if credentials.Token != "" {
+		headers.Set("Authorization", "Token "+string(credentials.Token))
+		set = append(set, "token")
+	}
+	if credentials.BearerToken != "" {
+		headers.Set("Authorization", "Bearer "+string(credentials.BearerToken))
+		set = append(set, "bearerToken")
+	}
+	if credentials.Username != "" {
+		encoded := base64.StdEncoding.EncodeToString([]byte(credentials.Username + ":" + string(credentials.Password)))
+		headers.Set("Authorization", "Basic "+encoded)
+		set = append(set, "username/password")
+	}
+	if credentials.Password != "" && credentials.Username == "" {
+		return nil, fmt.Errorf("Invalid %s password, it requires a username", backend)
+	}
+	if len(set) > 1 {
+		return nil, fmt.Errorf("Only one of %s %s may be set", backend, strings.Join(set, " and "))
+	}
+	return headers, nil
+}
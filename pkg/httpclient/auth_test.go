@@ -0,0 +1,32 @@
+package httpclient
+
+import (
+	"testing"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaders(t *testing.T) {
+	headers, err := Headers("influx", map[string]config.Secret{"x-scope": "naemon"}, Credentials{Username: "user", Password: "pass"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", headers.Get("Authorization"))
+	assert.Equal(t, "naemon", headers.Get("X-Scope"))
+
+	headers, err = Headers("influx", nil, Credentials{Token: "secret"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Token secret", headers.Get("Authorization"))
+
+	_, err = Headers("prometheus", nil, Credentials{BearerToken: "secret", Username: "user"})
+	assert.EqualError(t, err, "Only one of prometheus bearerToken and username/password may be set")
+
+	_, err = Headers("otlp", map[string]config.Secret{"authorization": "Bearer other"}, Credentials{BearerToken: "secret"})
+	assert.EqualError(t, err, "Only one of otlp headers.Authorization and bearerToken may be set")
+
+	headers, err = Headers("otlp", map[string]config.Secret{"authorization": "Bearer other"}, Credentials{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer other", headers.Get("Authorization"))
+
+	_, err = Headers("influx", nil, Credentials{Password: "pass"})
+	assert.EqualError(t, err, "Invalid influx password, it requires a username")
+}
@@ -0,0 +1,67 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+)
+
+// New creates an HTTP client that is meant to be kept for the lifetime of the process,
+// so that connections are kept alive and reused between requests
+func New(connection config.ConfigurationConnection, tlsCfg config.ConfigurationTLS) (*http.Client, error) {
+	tlsConfig, err := NewTLSConfig(tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConnsPerHost: connection.MaxConnections,
+		MaxConnsPerHost:     connection.MaxConnections,
+		IdleConnTimeout:     connection.IdleTimeoutSeconds * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+		// HTTP/2 is only negotiated for https, and has to be requested explicitly because of the custom TLS configuration
+		ForceAttemptHTTP2: connection.HTTP2,
+	}
+	if !connection.HTTP2 {
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   connection.RequestTimeoutSeconds * time.Second,
+	}, nil
+}
+
+// Ping checks that the url is reachable, for servers that have no health endpoint (e.g. remote write, OTLP/HTTP),
+// so any response except a server error counts as available
+// backend is the kind of server for the error message, e.g. Prometheus
+func Ping(client *http.Client, url string, headers http.Header, backend string) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range headers {
+		req.Header[key] = values
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("%s ping failed with status %s: %s", backend, resp.Status, ReadErrorMessage(resp.Body))
+	}
+	drain(resp.Body)
+	return nil
+}
+
+// drain reads the rest of a response body, the connection is only reused if the body was read completely
+func drain(body io.Reader) {
+	io.Copy(io.Discard, body)
+}
@@ -0,0 +1,60 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	client, err := New(config.ConfigurationConnection{MaxConnections: 4, IdleTimeoutSeconds: 90, RequestTimeoutSeconds: 30}, config.ConfigurationTLS{})
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, client.Timeout)
+	transport := client.Transport.(*http.Transport)
+	assert.Equal(t, 4, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+	assert.NotNil(t, transport.TLSNextProto)
+
+	_, err = New(config.ConfigurationConnection{}, config.ConfigurationTLS{MinVersion: "2.0"})
+	assert.NotNil(t, err)
+}
+
+func TestHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	for _, http2 := range []bool{true, false} {
+		client, err := New(config.ConfigurationConnection{HTTP2: http2}, config.ConfigurationTLS{InsecureSkipVerify: true})
+		assert.Nil(t, err)
+		resp, err := client.Get(server.URL)
+		assert.Nil(t, err)
+		resp.Body.Close()
+		if http2 {
+			assert.Equal(t, 2, resp.ProtoMajor)
+		} else {
+			assert.Equal(t, 1, resp.ProtoMajor)
+		}
+	}
+}
+
+func TestPing(t *testing.T) {
+	status := http.StatusMethodNotAllowed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "naemon", r.Header.Get("X-Scope"))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	headers := http.Header{"X-Scope": {"naemon"}}
+	assert.Nil(t, Ping(server.Client(), server.URL, headers, "Prometheus"))
+	status = http.StatusServiceUnavailable
+	assert.EqualError(t, Ping(server.Client(), server.URL, headers, "Prometheus"), "Prometheus ping failed with status 503 Service Unavailable: ")
+}
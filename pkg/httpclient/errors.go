@@ -0,0 +1,102 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteError is returned if a server responded to a write request with an error status
+type WriteError struct {
+	// Backend is the kind of server, e.g. Influx
+	Backend    string
+	StatusCode int
+	Status     string
+	Message    string
+	// RetryAfter is the delay requested by the server with a Retry-After header, 0 if none was sent
+	RetryAfter time.Duration
+}
+
+func (e *WriteError) Error() string {
+	return fmt.Sprintf("%s write failed with status %s: %s", e.Backend, e.Status, e.Message)
+}
+
+// CheckResponse returns a *WriteError if the response has an error status
+// otherwise it drains the body, see drain
+func CheckResponse(backend string, resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		drain(resp.Body)
+		return nil
+	}
+	return &WriteError{
+		Backend:    backend,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Message:    ReadErrorMessage(resp.Body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// ReadErrorMessage extracts the error message of a response body
+// influx returns {"error": "..."} (v1) or {"code": "...", "message": "..."} (v2), other servers usually plain text
+func ReadErrorMessage(body io.Reader) string {
+	content, err := io.ReadAll(io.LimitReader(body, 64*1024))
+	if err != nil {
+		return err.Error()
+	}
+	var message struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(content, &message) == nil {
+		if message.Error != "" {
+			return message.Error
+		}
+		if message.Message != "" {
+			return message.Message
+		}
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// IsPermanent returns true if the server rejected the data itself, so that sending it again would fail again:
+// bad request and partial writes (400), unprocessable data (422) and points that are too large (413),
+// which is only returned for single points, because larger batches are halved, see IsTooLarge
+// all other errors are transient, including authentication (401, 403) and missing databases (404),
+// which are resolved by fixing the configuration, e.g. after a credential rotation or during a migration
+func IsPermanent(err error) bool {
+	var writeErr *WriteError
+	if !errors.As(err, &writeErr) {
+		return false
+	}
+	switch writeErr.StatusCode {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return true
+	}
+	return false
+}
+
+// IsTooLarge returns true if the server rejected the request as too large (413), so that it should be sent in smaller batches
+func IsTooLarge(err error) bool {
+	var writeErr *WriteError
+	return errors.As(err, &writeErr) && writeErr.StatusCode == http.StatusRequestEntityTooLarge
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or a date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
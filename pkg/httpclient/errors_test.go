@@ -0,0 +1,27 @@
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPermanent(t *testing.T) {
+	assert.True(t, IsPermanent(&WriteError{StatusCode: http.StatusBadRequest}))
+	assert.True(t, IsPermanent(fmt.Errorf("Giving up after 3 attempts: %w", &WriteError{StatusCode: http.StatusUnprocessableEntity})))
+	assert.False(t, IsPermanent(&WriteError{StatusCode: http.StatusUnauthorized}))
+	assert.False(t, IsPermanent(fmt.Errorf("connection refused")))
+
+	assert.True(t, IsTooLarge(&WriteError{StatusCode: http.StatusRequestEntityTooLarge}))
+	assert.False(t, IsTooLarge(&WriteError{StatusCode: http.StatusBadRequest}))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 6, 11, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, 120*time.Second, parseRetryAfter("120", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter("Fri, 11 Jun 2021 10:00:30 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
@@ -0,0 +1,130 @@
+package influx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/httpclient"
+	"github.com/max-bytes/metrics-sender/pkg/sinkutil"
+)
+
+// BatchFailure describes a sub-batch of points that could not be written
+type BatchFailure struct {
+	// Target is the target chosen by a route, empty for the target of the configuration
+	Target Target
+	// Offset is the index of the first point of the sub-batch among the points of the target
+	Offset int
+	Points []*influxdb1.Point
+	Err    error
+}
+
+// BatchError is returned by Send if some of the sub-batches could not be written
+type BatchError struct {
+	Total    int
+	Failures []BatchFailure
+}
+
+func (e *BatchError) Error() string {
+	failed := 0
+	reasons := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		failed += len(failure.Points)
+		var target string
+		if failure.Target != (Target{}) {
+			target = failure.Target.String() + " "
+		}
+		if len(failure.Points) == 1 {
+			reasons = append(reasons, fmt.Sprintf("%spoint %d: %v", target, failure.Offset, failure.Err))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("%spoints %d-%d: %v", target, failure.Offset, failure.Offset+len(failure.Points)-1, failure.Err))
+		}
+	}
+	return fmt.Sprintf("Could not write %d of %d points: %s", failed, e.Total, strings.Join(reasons, "; "))
+}
+
+// IsPermanent returns true if the error cannot be resolved by retrying the same request,
+// e.g. because influx rejected the points as invalid (bad request, partial write)
+// server errors and network errors are transient, a *BatchError is permanent if all of its failures are
+func IsPermanent(err error) bool {
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) {
+		for _, failure := range batchErr.Failures {
+			if !IsPermanent(failure.Err) {
+				return false
+			}
+		}
+		return true
+	}
+	return httpclient.IsPermanent(err)
+}
+
+// FailedPoints returns the points that were not written because of the error returned by Send
+func FailedPoints(err error, points []*influxdb1.Point) []*influxdb1.Point {
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		return points
+	}
+	var failed []*influxdb1.Point
+	for _, failure := range batchErr.Failures {
+		failed = append(failed, failure.Points...)
+	}
+	return failed
+}
+
+// splitBatches splits the points into batches of at most maxPoints points and maxBytes bytes of line protocol, 0 means no limit
+// a single point that is larger than maxBytes gets a batch of its own
+func splitBatches(points []*influxdb1.Point, maxPoints int, maxBytes int, precision string) [][]*influxdb1.Point {
+	var batches [][]*influxdb1.Point
+	start, size := 0, 0
+	for i, point := range points {
+		pointSize := len(point.PrecisionString(precision)) + 1
+		full := maxPoints > 0 && i-start >= maxPoints
+		full = full || (maxBytes > 0 && size+pointSize > maxBytes)
+		if full && i > start {
+			batches = append(batches, points[start:i])
+			start, size = i, 0
+		}
+		size += pointSize
+	}
+	if start < len(points) {
+		batches = append(batches, points[start:])
+	}
+	return batches
+}
+
+// batchSender writes batches, halving those that are too large for the server
+type batchSender struct {
+	client   Client
+	target   Target
+	retry    config.ConfigurationRetry
+	failures []BatchFailure
+}
+
+// send writes the batch into the current target, the batch starts at offset within the points of the target
+// failures are recorded; a transient error is returned as well, because the remaining batches would most likely fail the same way
+func (s *batchSender) send(points []*influxdb1.Point, offset int) error {
+	err := sinkutil.Retry(s.retry, func() error {
+		return s.client.Write(s.target, points)
+	})
+	if err == nil {
+		return nil
+	}
+
+	if httpclient.IsTooLarge(err) && len(points) > 1 {
+		half := len(points) / 2
+		if err := s.send(points[:half], offset); err != nil {
+			s.failures = append(s.failures, BatchFailure{Target: s.target, Offset: offset + half, Points: points[half:], Err: err})
+			return err
+		}
+		return s.send(points[half:], offset+half)
+	}
+
+	s.failures = append(s.failures, BatchFailure{Target: s.target, Offset: offset, Points: points, Err: err})
+	if IsPermanent(err) {
+		return nil
+	}
+	return err
+}
@@ -2,51 +2,153 @@ package influx
 
 import (
 	"fmt"
-	"regexp"
+	"math"
 	"strconv"
 	"time"
 
 	// protocol "github.com/influxdata/line-protocol"
 	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/perfdata"
+	"github.com/sirupsen/logrus"
 )
 
-func EncodeInfluxLines(variableTags map[string]string) ([]*influxdb1.Point, error) {
+// FieldError is returned if the value of one of the special keys (state, timestamp) is invalid
+type FieldError struct {
+	Key   string
+	Value string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("Could not parse %s %s into integer: %v", e.Key, e.Value, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
 
-	state, err := strconv.Atoi(variableTags["state"])
+// Encoder turns the key/value pairs of a check result into influx points
+type Encoder struct {
+	timestampKey string
+	stateKey     string
+	perfdataKey  string
+	units        *unitNormalizer
+	tags         *tagFilter
+	// keys that are stored as string fields instead of tags, either on the state point or in fieldMeasurement
+	fieldKeys         []string
+	fieldMeasurement  string
+	wide              bool
+	metricMeasurement *measurementName
+	stateMeasurement  *measurementName
+	log               logrus.FieldLogger
+}
+
+func NewEncoder(template config.ConfigurationTemplate, encoder config.ConfigurationEncoder, log logrus.FieldLogger) (*Encoder, error) {
+	units, err := newUnitNormalizer(encoder.UnitNormalization)
 	if err != nil {
-		return nil, fmt.Errorf("Could not parse state %s into integer: %v", variableTags["state"], err)
+		return nil, err
 	}
-	delete(variableTags, "state")
+	tags, err := newTagFilter(encoder.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if encoder.Schema.Layout != config.LayoutNarrow && encoder.Schema.Layout != config.LayoutWide {
+		return nil, fmt.Errorf("Invalid layout %s, must be one of %s, %s", encoder.Schema.Layout, config.LayoutNarrow, config.LayoutWide)
+	}
+	metricMeasurement, err := newMeasurementName(encoder.Schema.MetricMeasurement)
+	if err != nil {
+		return nil, err
+	}
+	stateMeasurement, err := newMeasurementName(encoder.Schema.StateMeasurement)
+	if err != nil {
+		return nil, err
+	}
+	return &Encoder{
+		timestampKey:      template.TimestampKey,
+		stateKey:          template.StateKey,
+		perfdataKey:       template.PerfdataKey,
+		units:             units,
+		tags:              tags,
+		fieldKeys:         encoder.FieldKeys,
+		fieldMeasurement:  encoder.FieldMeasurement,
+		wide:              encoder.Schema.Layout == config.LayoutWide,
+		metricMeasurement: metricMeasurement,
+		stateMeasurement:  stateMeasurement,
+		log:               log,
+	}, nil
+}
 
-	perfdata := variableTags["perfdata"]
-	delete(variableTags, "perfdata")
+// EncodeInfluxLines creates a point for each perfdata label (or a single point for all labels in the wide layout) and a point for the state
+// the configured field keys are added as string fields, all remaining keys are added as tags to each point,
+// as far as the tag filter allows
+func (e *Encoder) EncodeInfluxLines(variableTags map[string]string) ([]*influxdb1.Point, error) {
 
-	timestampInt, err := strconv.ParseInt(variableTags["timestamp"], 10, 64)
+	state, err := strconv.Atoi(variableTags[e.stateKey])
 	if err != nil {
-		return nil, fmt.Errorf("Could not parse timestamp %s into integer: %v", variableTags["timestamp"], err)
+		return nil, &FieldError{Key: e.stateKey, Value: variableTags[e.stateKey], Err: err}
 	}
-	delete(variableTags, "timestamp")
+	delete(variableTags, e.stateKey)
+
+	perfdataStr := variableTags[e.perfdataKey]
+	delete(variableTags, e.perfdataKey)
+
+	timestampInt, err := strconv.ParseInt(variableTags[e.timestampKey], 10, 64)
+	if err != nil {
+		return nil, &FieldError{Key: e.timestampKey, Value: variableTags[e.timestampKey], Err: err}
+	}
+	delete(variableTags, e.timestampKey)
 	timestamp := time.Unix(timestampInt, 0)
 
-	metricPoints, err := perfData2Points(perfdata, variableTags, timestamp)
+	// free-text values like the plugin output must not become tags, because each distinct value would create a new series
+	stringFields := make(map[string]interface{}, len(e.fieldKeys))
+	for _, key := range e.fieldKeys {
+		if value, found := variableTags[key]; found {
+			stringFields[key] = value
+			delete(variableTags, key)
+		}
+	}
+
+	tags := e.tags.apply(variableTags)
+
+	metricPoints, err := e.perfData2Points(perfdataStr, variableTags, tags, timestamp)
 	if err != nil {
 		return nil, err
 	}
 
 	// add state as its own point, with the state encoded as an integer (0 to 3)
-	statePoint, err := state2point("state", state, variableTags, timestamp)
+	var stateFields map[string]interface{}
+	if e.fieldMeasurement == "" {
+		stateFields = stringFields
+	}
+	stateName, err := e.stateMeasurement.render(variableTags)
+	if err != nil {
+		return nil, err
+	}
+	statePoint, err := state2point(stateName, state, stateFields, tags, timestamp)
 	if err != nil {
 		return nil, err
 	}
 	allPoints := append(metricPoints, statePoint)
 
+	if e.fieldMeasurement != "" && len(stringFields) > 0 {
+		fieldPoint, err := influxdb1.NewPoint(e.fieldMeasurement, tags, stringFields, timestamp)
+		if err != nil {
+			return nil, err
+		}
+		allPoints = append(allPoints, fieldPoint)
+	}
+
 	return allPoints, nil
 }
 
-func state2point(metricName string, state int, addedTags map[string]string, timestamp time.Time) (*influxdb1.Point, error) {
+func state2point(metricName string, state int, addedFields map[string]interface{}, addedTags map[string]string, timestamp time.Time) (*influxdb1.Point, error) {
 	var fields = map[string]interface{}{
 		"value": state,
 	}
+	for fieldKey, fieldValue := range addedFields {
+		fields[fieldKey] = fieldValue
+	}
 
 	point, err := influxdb1.NewPoint(metricName, addedTags, fields, timestamp)
 	if err != nil {
@@ -56,54 +158,62 @@ func state2point(metricName string, state int, addedTags map[string]string, time
 	return point, nil
 }
 
-// partly taken from https://github.com/Griesbacher/nagflux/blob/ea877539bc49ed67e9a5e35b8a127b1ff4cadaad/collector/spoolfile/nagiosSpoolfileWorker.go
-var regexPerformanceLabel = regexp.MustCompile(`([^=]+)=(U|[\d\.,\-]+)([\pL\/%]*);?([\d\.,\-:~@]+)?;?([\d\.,\-:~@]+)?;?([\d\.,\-]+)?;?([\d\.,\-]+)?;?\s*`)
-
-func perfData2Points(str string, addedTags map[string]string, timestamp time.Time) ([]*influxdb1.Point, error) {
-	perfSlices := regexPerformanceLabel.FindAllStringSubmatch(str, -1)
+// perfData2Points creates a point for each perfdata label, with the label as tag
+// in the wide layout, a single point is created instead, with the fields of each label prefixed by the label
+// and its unit as string fields <label>_uom and <label>_original_uom
+// check contains the (unfiltered) keys of the check result, addedTags the tags that are added to each point
+func (e *Encoder) perfData2Points(str string, check map[string]string, addedTags map[string]string, timestamp time.Time) ([]*influxdb1.Point, error) {
+	data, errs := perfdata.Parse(str)
+	for _, err := range errs {
+		e.log.Warnf("Skipped invalid perfdata of host %s, service %s: %v", check["host"], check["service"], err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
 
-	points := make([]*influxdb1.Point, 0, len(perfSlices))
-	for _, perfSlice := range perfSlices {
-		label := perfSlice[1]
+	metricName, err := e.metricMeasurement.render(check)
+	if err != nil {
+		return nil, err
+	}
 
-		v, err := strconv.ParseFloat(perfSlice[2], 64)
+	if e.wide {
+		var fields = map[string]interface{}{}
+		for _, datum := range data {
+			datumFields, datumTags := e.datum2Fields(datum, check)
+			for fieldKey, fieldValue := range datumFields {
+				if fieldKey == "value" {
+					fields[datum.Label] = fieldValue
+				} else {
+					fields[datum.Label+"_"+fieldKey] = fieldValue
+				}
+			}
+			// without a label tag, the unit cannot be a tag, which would apply to all labels of the point
+			for tagKey, tagValue := range datumTags {
+				fields[datum.Label+"_"+tagKey] = tagValue
+			}
+		}
+		point, err := influxdb1.NewPoint(metricName, addedTags, fields, timestamp)
 		if err != nil {
-			fmt.Println(err)
-			continue
+			return nil, err
 		}
+		return []*influxdb1.Point{point}, nil
+	}
+
+	points := make([]*influxdb1.Point, 0, len(data))
+	for _, datum := range data {
+		fields, datumTags := e.datum2Fields(datum, check)
 
-		var fields = map[string]interface{}{
-			"value": v,
-		}
 		var tags = map[string]string{
-			"label": label,
+			"label": datum.Label,
 		}
 		for tagKey, tagValue := range addedTags {
 			tags[tagKey] = tagValue
 		}
-
-		// add UOM to tags, if present
-		if perfSlice[3] != "" {
-			tags["uom"] = perfSlice[3]
-		}
-		warnF, err := strconv.ParseFloat(perfSlice[4], 64)
-		if err == nil {
-			fields["warn"] = warnF
-		}
-		critF, err := strconv.ParseFloat(perfSlice[5], 64)
-		if err == nil {
-			fields["crit"] = critF
-		}
-		minF, err := strconv.ParseFloat(perfSlice[6], 64)
-		if err == nil {
-			fields["min"] = minF
-		}
-		maxF, err := strconv.ParseFloat(perfSlice[7], 64)
-		if err == nil {
-			fields["max"] = maxF
+		for tagKey, tagValue := range datumTags {
+			tags[tagKey] = tagValue
 		}
 
-		point, err := influxdb1.NewPoint("metric", tags, fields, timestamp)
+		point, err := influxdb1.NewPoint(metricName, tags, fields, timestamp)
 		if err != nil {
 			return nil, err
 		}
@@ -111,3 +221,66 @@ func perfData2Points(str string, addedTags map[string]string, timestamp time.Tim
 	}
 	return points, nil
 }
+
+// datum2Fields returns the fields of a single perfdata label, and the tags describing its unit
+func (e *Encoder) datum2Fields(datum perfdata.Datum, check map[string]string) (map[string]interface{}, map[string]string) {
+	// optionally convert values into base units, keeping the original unit as tag
+	uom, factor := e.units.normalize(datum.UOM, check["service"])
+
+	var fields = map[string]interface{}{}
+	if datum.Unknown {
+		// the plugin could not determine the value, which is stored explicitly
+		fields["unknown"] = true
+	} else {
+		fields["value"] = datum.Value * factor
+	}
+
+	// add UOM to tags, if present
+	var tags = map[string]string{}
+	if uom != "" {
+		tags["uom"] = uom
+	}
+	if uom != datum.UOM {
+		tags["original_uom"] = datum.UOM
+	}
+
+	e.addThresholdFields(fields, "warn", datum.Warn, factor, check)
+	e.addThresholdFields(fields, "crit", datum.Crit, factor, check)
+	minF, err := perfdata.ParseFloat(datum.Min)
+	if err == nil {
+		fields["min"] = minF * factor
+	}
+	maxF, err := perfdata.ParseFloat(datum.Max)
+	if err == nil {
+		fields["max"] = maxF * factor
+	}
+	return fields, tags
+}
+
+// addThresholdFields adds the threshold as <name>_min, <name>_max and <name>_inverted fields, omitting infinite bounds
+// simple thresholds that consist of a single number are additionally added as <name>, like before ranges were supported,
+// even if they are no valid range (e.g. -5, which would be the range 0 to -5)
+func (e *Encoder) addThresholdFields(fields map[string]interface{}, name string, threshold string, factor float64, check map[string]string) {
+	if threshold == "" {
+		return
+	}
+	simple, simpleErr := perfdata.ParseFloat(threshold)
+	if simpleErr == nil {
+		fields[name] = simple * factor
+	}
+	r, err := perfdata.ParseRange(threshold)
+	if err != nil {
+		if simpleErr != nil {
+			e.log.Warnf("Skipped invalid %s threshold of host %s, service %s: %v", name, check["host"], check["service"], err)
+		}
+		return
+	}
+
+	if !math.IsInf(r.Min, 0) {
+		fields[name+"_min"] = r.Min * factor
+	}
+	if !math.IsInf(r.Max, 0) {
+		fields[name+"_max"] = r.Max * factor
+	}
+	fields[name+"_inverted"] = r.Inverted
+}
@@ -0,0 +1,43 @@
+package influx
+
+import (
+	"testing"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTemplate = config.ConfigurationTemplate{TimestampKey: "timestamp", StateKey: "state", PerfdataKey: "perfdata"}
+
+func TestEncodeWide(t *testing.T) {
+	encoder, err := NewEncoder(testTemplate, config.ConfigurationEncoder{
+		UnitNormalization: config.ConfigurationUnitNormalization{Enabled: true},
+		Schema:            config.ConfigurationSchema{Layout: config.LayoutWide, MetricMeasurement: "metric", StateMeasurement: "state"},
+	}, logrus.StandardLogger())
+	assert.Nil(t, err)
+
+	points, err := encoder.EncodeInfluxLines(map[string]string{
+		"timestamp": "1623407324",
+		"host":      "host123",
+		"state":     "1",
+		"perfdata":  "used=2MB;3 rta=1500ms pl=5% count=3",
+	})
+	assert.Nil(t, err)
+	assert.Len(t, points, 2)
+	// the units of all labels are kept, including the original unit of normalized values
+	assert.Equal(t, `metric,host=host123 count=3,pl=5,pl_uom="%",rta=1.5,rta_original_uom="ms",rta_uom="s",used=2097152,used_original_uom="MB",used_uom="B",used_warn=3145728,used_warn_inverted=false,used_warn_max=3145728,used_warn_min=0 1623407324000000000`, points[0].String())
+	assert.Equal(t, "state,host=host123 value=1i 1623407324000000000", points[1].String())
+}
+
+func TestEncodeNarrow(t *testing.T) {
+	encoder, err := NewEncoder(testTemplate, config.ConfigurationEncoder{
+		Schema: config.ConfigurationSchema{Layout: config.LayoutNarrow, MetricMeasurement: "metric", StateMeasurement: "state"},
+	}, logrus.StandardLogger())
+	assert.Nil(t, err)
+
+	points, err := encoder.EncodeInfluxLines(map[string]string{"timestamp": "1623407324", "host": "host123", "state": "0", "perfdata": "rta=1.5ms"})
+	assert.Nil(t, err)
+	assert.Len(t, points, 2)
+	assert.Equal(t, "metric,host=host123,label=rta,uom=ms value=1.5 1623407324000000000", points[0].String())
+}
@@ -0,0 +1,71 @@
+package influx
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+)
+
+type baseUnit struct {
+	uom    string
+	factor float64
+}
+
+// baseUnits maps the units of measurement of the Nagios plugin guidelines to their base units
+// byte prefixes are binary, like in the standard plugins (e.g. check_disk); % and c are not converted
+var baseUnits = map[string]baseUnit{
+	"B":  {"B", 1},
+	"KB": {"B", 1 << 10},
+	"MB": {"B", 1 << 20},
+	"GB": {"B", 1 << 30},
+	"TB": {"B", 1 << 40},
+	"PB": {"B", 1 << 50},
+	"s":  {"s", 1},
+	"ms": {"s", 1e-3},
+	"us": {"s", 1e-6},
+}
+
+// unitNormalizer decides for which services values are converted into base units
+type unitNormalizer struct {
+	enabled bool
+	rules   []unitNormalizationRule
+}
+
+type unitNormalizationRule struct {
+	service *regexp.Regexp
+	enabled bool
+}
+
+func newUnitNormalizer(cfg config.ConfigurationUnitNormalization) (*unitNormalizer, error) {
+	n := &unitNormalizer{enabled: cfg.Enabled}
+	for _, rule := range cfg.Rules {
+		service, err := regexp.Compile(rule.Service)
+		if err != nil {
+			return nil, fmt.Errorf("Could not compile service pattern %s of unit normalization: %v", rule.Service, err)
+		}
+		n.rules = append(n.rules, unitNormalizationRule{service: service, enabled: rule.Enabled})
+	}
+	return n, nil
+}
+
+// normalize returns the base unit and the factor to convert values of the given unit into it
+// if the unit is unknown or normalization is disabled for the service, the unit is returned unchanged with factor 1
+func (n *unitNormalizer) normalize(uom string, service string) (string, float64) {
+	enabled := n.enabled
+	// the first matching rule wins
+	for _, rule := range n.rules {
+		if rule.service.MatchString(service) {
+			enabled = rule.enabled
+			break
+		}
+	}
+	if !enabled {
+		return uom, 1
+	}
+	base, found := baseUnits[uom]
+	if !found {
+		return uom, 1
+	}
+	return base.uom, base.factor
+}
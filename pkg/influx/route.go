@@ -0,0 +1,66 @@
+package influx
+
+import (
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/route"
+)
+
+type influxRoute struct {
+	matcher *route.Matcher
+	target  Target
+}
+
+// Router chooses the target of each point, it is created once for the routes of a configuration and passed to Send
+type Router struct {
+	routes []influxRoute
+}
+
+func NewRouter(routes []config.ConfigurationInfluxRoute) (*Router, error) {
+	r := &Router{}
+	for _, routeCfg := range routes {
+		matcher, err := route.NewMatcher(routeCfg.Match)
+		if err != nil {
+			return nil, err
+		}
+		r.routes = append(r.routes, influxRoute{
+			matcher: matcher,
+			target:  Target{Database: routeCfg.Database, RetentionPolicy: routeCfg.RetentionPolicy, Org: routeCfg.Org, Bucket: routeCfg.Bucket},
+		})
+	}
+	return r, nil
+}
+
+// targetPoints are the points that are written into the same target
+type targetPoints struct {
+	target Target
+	points []*influxdb1.Point
+}
+
+// split groups the points by the target of the first matching route, keeping their order
+// points that match no route are written into the target of the configuration, the empty target; so are all points if r is nil
+func (r *Router) split(points []*influxdb1.Point) []targetPoints {
+	if r == nil || len(r.routes) == 0 {
+		return []targetPoints{{points: points}}
+	}
+	var groups []targetPoints
+	indexes := map[Target]int{}
+	for _, point := range points {
+		var target Target
+		tags := point.Tags()
+		for _, route := range r.routes {
+			if route.matcher.Matches(tags) {
+				target = route.target
+				break
+			}
+		}
+		index, found := indexes[target]
+		if !found {
+			index = len(groups)
+			indexes[target] = index
+			groups = append(groups, targetPoints{target: target})
+		}
+		groups[index].points = append(groups[index].points, point)
+	}
+	return groups
+}
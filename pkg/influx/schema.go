@@ -0,0 +1,43 @@
+package influx
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// measurementName is the name of a measurement, which may be a template (e.g. {{.service}})
+// that is rendered with the keys of each check result
+type measurementName struct {
+	name     string
+	template *template.Template // nil if the name is constant
+}
+
+func newMeasurementName(name string) (*measurementName, error) {
+	if name == "" {
+		return nil, fmt.Errorf("Measurement name must not be empty")
+	}
+	if !strings.Contains(name, "{{") {
+		return &measurementName{name: name}, nil
+	}
+	t, err := template.New("measurement").Option("missingkey=zero").Parse(name)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse measurement name template %s: %v", name, err)
+	}
+	return &measurementName{name: name, template: t}, nil
+}
+
+func (m *measurementName) render(check map[string]string) (string, error) {
+	if m.template == nil {
+		return m.name, nil
+	}
+	var name strings.Builder
+	err := m.template.Execute(&name, check)
+	if err != nil {
+		return "", fmt.Errorf("Could not render measurement name %s: %v", m.name, err)
+	}
+	if name.Len() == 0 {
+		return "", fmt.Errorf("Measurement name %s rendered to an empty string", m.name)
+	}
+	return name.String(), nil
+}
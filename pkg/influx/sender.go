@@ -1,38 +1,282 @@
 package influx
 
 import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
 	influxdb1 "github.com/influxdata/influxdb1-client/v2"
 	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/httpclient"
 )
 
-func CreateInfluxConnection(config config.ConfigurationInflux) (influxdb1.Client, error) {
-	writeEncoding := influxdb1.DefaultEncoding
-	if config.GZip {
-		writeEncoding = influxdb1.GzipEncoding
+// Client writes points to an influx compatible write API
+type Client interface {
+	// Write writes the points into the target, empty values of the target are taken from the configuration
+	Write(target Target, points []*influxdb1.Point) error
+	Ping() error
+	Close() error
+}
+
+// precisions maps the precisions of the v2 API to the ones of the v1 API
+var precisions = map[string]string{
+	"ns": "n",
+	"us": "u",
+	"ms": "ms",
+	"s":  "s",
+}
+
+// Target is the database (v1) or bucket (v2) that points are written into
+type Target struct {
+	Database        string
+	RetentionPolicy string
+	Org             string
+	Bucket          string
+}
+
+// String describes a target that was chosen by a route
+func (t Target) String() string {
+	if t.Bucket != "" {
+		return "bucket " + t.Bucket
+	}
+	if t.RetentionPolicy != "" {
+		return "database " + t.Database + "." + t.RetentionPolicy
+	}
+	return "database " + t.Database
+}
+
+type httpClient struct {
+	writeURL  url.URL // without target
+	target    Target  // of the configuration
+	v2        bool
+	pingURL   string
+	precision string // in v1 notation, used to encode the points
+	gzip      bool
+	headers   http.Header
+	http      *http.Client
+}
+
+// CreateInfluxConnection creates a client for the v1 (/write) or the v2 (/api/v2/write) write API
+// InfluxDB 3.x supports the v2 write API as well
+func CreateInfluxConnection(config config.ConfigurationInflux) (Client, error) {
+	baseURL, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse influx url %s: %v", config.URL, err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("Unsupported protocol scheme %s of influx url %s", baseURL.Scheme, config.URL)
+	}
+
+	precision := config.Precision
+	if precision == "" {
+		precision = "ns"
 	}
-	c, err := influxdb1.NewHTTPClient(influxdb1.HTTPConfig{
-		Addr:               config.URL,
-		InsecureSkipVerify: true,
-		WriteEncoding:      writeEncoding,
+	v1Precision, found := precisions[precision]
+	if !found {
+		return nil, fmt.Errorf("Invalid precision %s, must be one of ns, us, ms, s", precision)
+	}
+
+	// the target is added to the query of each request
+	writeURL := *baseURL
+	query := url.Values{}
+	switch config.APIVersion {
+	case 0, 1:
+		writeURL.Path = path.Join(writeURL.Path, "write")
+		if config.Consistency != "" {
+			query.Set("consistency", config.Consistency)
+		}
+		query.Set("precision", v1Precision)
+	case 2, 3:
+		if config.Bucket == "" {
+			return nil, fmt.Errorf("Influx bucket must be set for api version %d", config.APIVersion)
+		}
+		writeURL.Path = path.Join(writeURL.Path, "api/v2/write")
+		query.Set("precision", precision)
+	default:
+		return nil, fmt.Errorf("Invalid influx api version %d, must be one of 1, 2, 3", config.APIVersion)
+	}
+	writeURL.RawQuery = query.Encode()
+
+	pingURL := *baseURL
+	pingURL.Path = path.Join(pingURL.Path, "ping")
+
+	headers, err := createHeaders(config)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := httpclient.New(config.Connection, config.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpClient{
+		writeURL:  writeURL,
+		target:    Target{Database: config.Database, RetentionPolicy: config.RetentionPolicy, Org: config.Org, Bucket: config.Bucket},
+		v2:        config.APIVersion == 2 || config.APIVersion == 3,
+		pingURL:   pingURL.String(),
+		precision: v1Precision,
+		gzip:      config.GZip,
+		headers:   headers,
+		http:      client,
+	}, nil
+}
+
+// createHeaders adds the content type of line protocol to the configured headers
+func createHeaders(config config.ConfigurationInflux) (http.Header, error) {
+	headers, err := httpclient.Headers("influx", config.Headers, httpclient.Credentials{
+		Token:       config.Token,
+		BearerToken: config.BearerToken,
+		Username:    config.Username,
+		Password:    config.Password,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return c, nil
+	headers.Set("Content-Type", "text/plain; charset=utf-8")
+	return headers, nil
+}
+
+// targetURL returns the write url of the target
+func (c *httpClient) targetURL(target Target) string {
+	if target.Database == "" {
+		target.Database = c.target.Database
+	}
+	if target.RetentionPolicy == "" {
+		target.RetentionPolicy = c.target.RetentionPolicy
+	}
+	if target.Org == "" {
+		target.Org = c.target.Org
+	}
+	if target.Bucket == "" {
+		target.Bucket = c.target.Bucket
+	}
+
+	writeURL := c.writeURL
+	query := writeURL.Query()
+	if c.v2 {
+		if target.Org != "" {
+			query.Set("org", target.Org)
+		}
+		query.Set("bucket", target.Bucket)
+	} else {
+		query.Set("db", target.Database)
+		if target.RetentionPolicy != "" {
+			query.Set("rp", target.RetentionPolicy)
+		}
+	}
+	writeURL.RawQuery = query.Encode()
+	return writeURL.String()
 }
 
-func Send(writePoints []*influxdb1.Point, client influxdb1.Client, config config.ConfigurationInflux) error {
+func (c *httpClient) Write(target Target, points []*influxdb1.Point) error {
+	var body bytes.Buffer
+	var w io.Writer = &body
+	var gzipWriter *gzip.Writer
+	if c.gzip {
+		gzipWriter = gzip.NewWriter(&body)
+		w = gzipWriter
+	}
+	for _, point := range points {
+		_, err := io.WriteString(w, point.PrecisionString(c.precision)+"\n")
+		if err != nil {
+			return err
+		}
+	}
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			return err
+		}
+	}
 
-	bp, err := influxdb1.NewBatchPoints(influxdb1.BatchPointsConfig{Database: config.Database})
+	req, err := http.NewRequest(http.MethodPost, c.targetURL(target), &body)
 	if err != nil {
 		return err
 	}
-	bp.AddPoints(writePoints)
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+	if c.gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 
-	err = client.Write(bp)
+	resp, err := c.http.Do(req)
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
+
+	return httpclient.CheckResponse("Influx", resp)
+}
 
+func (c *httpClient) Ping() error {
+	req, err := http.NewRequest(http.MethodGet, c.pingURL, nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Influx ping failed with status %s: %s", resp.Status, httpclient.ReadErrorMessage(resp.Body))
+	}
+	return nil
+}
+
+func (c *httpClient) Close() error {
+	c.http.CloseIdleConnections()
 	return nil
 }
+
+// Send writes the points in batches of line protocol, which are retried and halved like in sinkutil.SendBatches
+// each point is written into the target of the first matching route of the router (which may be nil), so the points may be split
+// across several databases or buckets; the routes of the configuration are not used, see NewRouter
+// if only some batches fail, a *BatchError is returned; use IsPermanent to check whether a returned error is worth retrying later
+func Send(writePoints []*influxdb1.Point, client Client, router *Router, config config.ConfigurationInflux) error {
+	if len(writePoints) == 0 {
+		return nil
+	}
+
+	precision, found := precisions[config.Precision]
+	if !found {
+		precision = "n"
+	}
+	sender := &batchSender{client: client, retry: config.Retry}
+	var stopErr error
+	for _, group := range router.split(writePoints) {
+		sender.target = group.target
+		if stopErr != nil {
+			// a transient failure before, the remaining points would most likely fail the same way
+			sender.failures = append(sender.failures, BatchFailure{Target: group.target, Points: group.points, Err: stopErr})
+			continue
+		}
+		offset := 0
+		for _, batch := range splitBatches(group.points, config.Batch.MaxPoints, config.Batch.MaxBytes, precision) {
+			if stopErr = sender.send(batch, offset); stopErr != nil {
+				if rest := group.points[offset+len(batch):]; len(rest) > 0 {
+					sender.failures = append(sender.failures, BatchFailure{Target: group.target, Offset: offset + len(batch), Points: rest, Err: stopErr})
+				}
+				break
+			}
+			offset += len(batch)
+		}
+	}
+
+	switch {
+	case len(sender.failures) == 0:
+		return nil
+	case len(sender.failures) == 1 && len(sender.failures[0].Points) == len(writePoints):
+		return sender.failures[0].Err
+	default:
+		return &BatchError{Total: len(writePoints), Failures: sender.failures}
+	}
+}
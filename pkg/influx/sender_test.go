@@ -0,0 +1,242 @@
+package influx
+
+import (
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedRequest struct {
+	method string
+	url    string
+	header http.Header
+	body   string
+}
+
+func newTestServer(t *testing.T, status int) (*httptest.Server, *[]recordedRequest) {
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, recordedRequest{method: r.Method, url: r.URL.String(), header: r.Header, body: string(body)})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func testPoints(t *testing.T) []*influxdb1.Point {
+	point, err := influxdb1.NewPoint("state", map[string]string{"host": "host123"}, map[string]interface{}{"value": 0}, time.Unix(1623407324, 0))
+	assert.Nil(t, err)
+	return []*influxdb1.Point{point}
+}
+
+func TestSendV1(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusNoContent)
+	cfg := config.ConfigurationInflux{URL: server.URL + "/api/influx/v1", Database: "naemon", RetentionPolicy: "autogen", Precision: "s"}
+
+	client, err := CreateInfluxConnection(cfg)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	assert.Nil(t, Send(testPoints(t), client, nil, cfg))
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, "/api/influx/v1/write?db=naemon&precision=s&rp=autogen", (*requests)[0].url)
+	assert.Equal(t, "state,host=host123 value=0i 1623407324\n", (*requests)[0].body)
+}
+
+func TestSendV2(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusNoContent)
+	cfg := config.ConfigurationInflux{URL: server.URL, APIVersion: 2, Org: "max", Bucket: "naemon", Token: "secret"}
+
+	client, err := CreateInfluxConnection(cfg)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	assert.Nil(t, Send(testPoints(t), client, nil, cfg))
+	assert.Len(t, *requests, 1)
+	assert.Equal(t, "/api/v2/write?bucket=naemon&org=max&precision=ns", (*requests)[0].url)
+	assert.Equal(t, "Token secret", (*requests)[0].header.Get("Authorization"))
+	assert.Equal(t, "state,host=host123 value=0i 1623407324000000000\n", (*requests)[0].body)
+}
+
+func TestSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"code":"invalid","message":"unable to parse points"}`)
+	}))
+	defer server.Close()
+	cfg := config.ConfigurationInflux{URL: server.URL, APIVersion: 2, Bucket: "naemon"}
+
+	client, err := CreateInfluxConnection(cfg)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	err = Send(testPoints(t), client, nil, cfg)
+	assert.EqualError(t, err, "Influx write failed with status 400 Bad Request: unable to parse points")
+}
+
+func TestAuthentication(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusNoContent)
+
+	for _, cfg := range []config.ConfigurationInflux{
+		{URL: server.URL, Username: "user", Password: "pass", Headers: map[string]config.Secret{"X-Scope": "naemon"}},
+		{URL: server.URL, BearerToken: "secret"},
+	} {
+		client, err := CreateInfluxConnection(cfg)
+		assert.Nil(t, err)
+		assert.Nil(t, Send(testPoints(t), client, nil, cfg))
+		client.Close()
+	}
+
+	assert.Len(t, *requests, 2)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", (*requests)[0].header.Get("Authorization"))
+	assert.Equal(t, "naemon", (*requests)[0].header.Get("X-Scope"))
+	assert.Equal(t, "Bearer secret", (*requests)[1].header.Get("Authorization"))
+
+	_, err := CreateInfluxConnection(config.ConfigurationInflux{URL: server.URL, Token: "a", BearerToken: "b"})
+	assert.NotNil(t, err)
+	_, err = CreateInfluxConnection(config.ConfigurationInflux{URL: server.URL, Token: "a", Headers: map[string]config.Secret{"Authorization": "Token b"}})
+	assert.NotNil(t, err)
+	_, err = CreateInfluxConnection(config.ConfigurationInflux{URL: server.URL, Password: "pass"})
+	assert.NotNil(t, err)
+}
+
+func TestTLSVerification(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// the certificate of the test server is not trusted by default
+	client, err := CreateInfluxConnection(config.ConfigurationInflux{URL: server.URL})
+	assert.Nil(t, err)
+	assert.NotNil(t, client.Ping())
+	client.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0644))
+	client, err = CreateInfluxConnection(config.ConfigurationInflux{URL: server.URL, TLS: config.ConfigurationTLS{CAFile: caFile}})
+	assert.Nil(t, err)
+	assert.Nil(t, client.Ping())
+	client.Close()
+
+	client, err = CreateInfluxConnection(config.ConfigurationInflux{URL: server.URL, TLS: config.ConfigurationTLS{InsecureSkipVerify: true}})
+	assert.Nil(t, err)
+	assert.Nil(t, client.Ping())
+	client.Close()
+}
+
+func TestSplitBatches(t *testing.T) {
+	var points []*influxdb1.Point
+	for i := 0; i < 5; i++ {
+		points = append(points, testPoints(t)...)
+	}
+	size := len(points[0].PrecisionString("s")) + 1
+
+	batches := splitBatches(points, 2, 0, "s")
+	assert.Equal(t, [][]*influxdb1.Point{points[0:2], points[2:4], points[4:5]}, batches)
+
+	batches = splitBatches(points, 0, 3*size, "s")
+	assert.Equal(t, [][]*influxdb1.Point{points[0:3], points[3:5]}, batches)
+
+	// a point larger than the limit is sent on its own
+	batches = splitBatches(points, 0, size-1, "s")
+	assert.Len(t, batches, 5)
+
+	assert.Equal(t, [][]*influxdb1.Point{points}, splitBatches(points, 0, 0, "s"))
+}
+
+func TestSendBatches(t *testing.T) {
+	var points []*influxdb1.Point
+	for i := 0; i < 10; i++ {
+		point, err := influxdb1.NewPoint("state", map[string]string{"host": "host123"}, map[string]interface{}{"value": i}, time.Unix(1623407324, 0))
+		assert.Nil(t, err)
+		points = append(points, point)
+	}
+
+	// accepts at most 2 points per request, rejects the point with value 7
+	var batchSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lines := strings.Count(string(body), "\n")
+		batchSizes = append(batchSizes, lines)
+		switch {
+		case lines > 2:
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		case strings.Contains(string(body), "value=7i"):
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"error":"invalid point"}`)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	cfg := config.ConfigurationInflux{URL: server.URL, Database: "naemon", Batch: config.ConfigurationBatch{MaxPoints: 5}}
+
+	client, err := CreateInfluxConnection(cfg)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	err = Send(points, client, nil, cfg)
+	assert.Equal(t, []int{5, 2, 3, 1, 2, 5, 2, 3, 1, 2}, batchSizes)
+	var batchErr *BatchError
+	assert.True(t, errors.As(err, &batchErr))
+	assert.Len(t, batchErr.Failures, 1)
+	assert.Equal(t, 7, batchErr.Failures[0].Offset)
+	assert.Equal(t, points[7:8], batchErr.Failures[0].Points)
+	assert.Equal(t, points[7:8], FailedPoints(err, points))
+	assert.EqualError(t, err, "Could not write 1 of 10 points: point 7: Influx write failed with status 400 Bad Request: invalid point")
+	assert.True(t, IsPermanent(err))
+
+	// a transient failure stops sending the remaining batches
+	server.Close()
+	err = Send(points, client, nil, cfg)
+	assert.False(t, IsPermanent(err))
+	assert.True(t, errors.As(err, &batchErr))
+	assert.Len(t, batchErr.Failures, 2)
+	assert.Equal(t, 5, batchErr.Failures[1].Offset)
+	assert.Regexp(t, "^Could not write 10 of 10 points: points 0-4: .*; points 5-9: ", err.Error())
+}
+
+func TestSendRoutes(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusNoContent)
+	cfg := config.ConfigurationInflux{URL: server.URL, Database: "naemon", Precision: "s", Routes: []config.ConfigurationInfluxRoute{
+		{Match: map[string]config.ConfigurationMatch{"customer": {Exact: "acme"}}, Database: "naemon_acme"},
+		{Match: map[string]config.ConfigurationMatch{"customer": {Regex: "^glob"}}, RetentionPolicy: "short"},
+	}}
+
+	client, err := CreateInfluxConnection(cfg)
+	assert.Nil(t, err)
+	defer client.Close()
+	router, err := NewRouter(cfg.Routes)
+	assert.Nil(t, err)
+
+	var points []*influxdb1.Point
+	for _, customer := range []string{"acme", "globex", "initech", "acme"} {
+		point, err := influxdb1.NewPoint("state", map[string]string{"customer": customer}, map[string]interface{}{"value": 0}, time.Unix(1623407324, 0))
+		assert.Nil(t, err)
+		points = append(points, point)
+	}
+
+	assert.Nil(t, Send(points, client, router, cfg))
+	assert.Len(t, *requests, 3)
+	assert.Equal(t, "/write?db=naemon_acme&precision=s", (*requests)[0].url)
+	assert.Equal(t, "state,customer=acme value=0i 1623407324\nstate,customer=acme value=0i 1623407324\n", (*requests)[0].body)
+	assert.Equal(t, "/write?db=naemon&precision=s&rp=short", (*requests)[1].url)
+	assert.Equal(t, "/write?db=naemon&precision=s", (*requests)[2].url)
+	assert.Equal(t, "state,customer=initech value=0i 1623407324\n", (*requests)[2].body)
+
+	_, err = NewRouter(append(cfg.Routes, config.ConfigurationInfluxRoute{Match: map[string]config.ConfigurationMatch{"customer": {Regex: "("}}}))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,54 @@
+package influx
+
+import (
+	"fmt"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+)
+
+// tagFilter decides which keys of a check result become tags, and under which name
+type tagFilter struct {
+	allow        map[string]bool
+	deny         map[string]bool
+	rename       map[string]string
+	allowUnknown bool
+}
+
+func newTagFilter(cfg config.ConfigurationTags) (*tagFilter, error) {
+	f := &tagFilter{
+		allow:  map[string]bool{},
+		deny:   map[string]bool{},
+		rename: cfg.Rename,
+	}
+	switch cfg.Default {
+	case "", config.TagDefaultAllow:
+		f.allowUnknown = true
+	case config.TagDefaultDeny:
+		f.allowUnknown = false
+	default:
+		return nil, fmt.Errorf("Invalid tag default %s, must be one of %s, %s", cfg.Default, config.TagDefaultAllow, config.TagDefaultDeny)
+	}
+	for _, key := range cfg.Allow {
+		f.allow[key] = true
+	}
+	for _, key := range cfg.Deny {
+		f.deny[key] = true
+	}
+	return f, nil
+}
+
+// apply returns the tags that result from the given keys: denied keys are dropped, keys that are neither
+// allowed nor denied are kept according to the default, and the remaining keys are renamed
+func (f *tagFilter) apply(keys map[string]string) map[string]string {
+	tags := make(map[string]string, len(keys))
+	for key, value := range keys {
+		if f.deny[key] || (!f.allow[key] && !f.allowUnknown) {
+			continue
+		}
+		if renamed, found := f.rename[key]; found {
+			key = renamed
+		}
+		tags[key] = value
+	}
+	return tags
+}
@@ -0,0 +1,169 @@
+package otlp
+
+import (
+	"sort"
+	"strings"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/sinkutil"
+)
+
+// the subset of the OTLP metrics messages that is needed for gauges, the json tags follow the OTLP/JSON encoding
+
+type exportRequest struct {
+	ResourceMetrics []resourceMetrics `json:"resourceMetrics"`
+}
+
+type resourceMetrics struct {
+	Resource     resource       `json:"resource"`
+	ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeMetrics struct {
+	Scope   scope    `json:"scope"`
+	Metrics []metric `json:"metrics"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type metric struct {
+	Name  string `json:"name"`
+	Gauge gauge  `json:"gauge"`
+}
+
+type gauge struct {
+	DataPoints []dataPoint `json:"dataPoints"`
+}
+
+type dataPoint struct {
+	Attributes   []keyValue `json:"attributes,omitempty"`
+	TimeUnixNano int64      `json:"timeUnixNano,string"`
+	AsDouble     float64    `json:"asDouble"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// scopeName identifies metrics-sender as the instrumentation scope of the metrics
+const scopeName = "metrics-sender"
+
+// converter turns points into gauges
+type converter struct {
+	prefix             string
+	stateMeasurement   string
+	resourceAttributes map[string]string
+}
+
+// convert creates a data point for each numeric field of the points, grouped by resource and metric
+//   - points of the state measurement become <prefix>.check.state
+//   - other points become <prefix>.perfdata, other fields than value are added as suffix, e.g. <prefix>.perfdata.warn
+//
+// the tags of resourceAttributes become attributes of the resource, the remaining tags (e.g. label and uom) attributes of the data points
+func (c *converter) convert(points []*influxdb1.Point) exportRequest {
+	type resourceGroup struct {
+		resource resource
+		metrics  map[string]*metric
+	}
+	groups := map[string]*resourceGroup{}
+	for _, point := range points {
+		fields, err := point.Fields()
+		if err != nil {
+			continue
+		}
+
+		var resourceAttributes, attributes []keyValue
+		for key, value := range point.Tags() {
+			if name, ok := c.resourceAttributes[key]; ok {
+				resourceAttributes = append(resourceAttributes, keyValue{Key: name, Value: anyValue{StringValue: value}})
+			} else {
+				attributes = append(attributes, keyValue{Key: key, Value: anyValue{StringValue: value}})
+			}
+		}
+		sortAttributes(resourceAttributes)
+		sortAttributes(attributes)
+
+		key := attributesKey(resourceAttributes)
+		group, found := groups[key]
+		if !found {
+			group = &resourceGroup{resource: resource{Attributes: resourceAttributes}, metrics: map[string]*metric{}}
+			groups[key] = group
+		}
+
+		base := c.prefix + ".perfdata"
+		if point.Name() == c.stateMeasurement {
+			base = c.prefix + ".check.state"
+		}
+		for field, value := range fields {
+			number, ok := sinkutil.ToFloat(value)
+			if !ok {
+				continue
+			}
+			name := base
+			if field != "value" {
+				name += "." + field
+			}
+			m, found := group.metrics[name]
+			if !found {
+				m = &metric{Name: name}
+				group.metrics[name] = m
+			}
+			m.Gauge.DataPoints = append(m.Gauge.DataPoints, dataPoint{Attributes: attributes, TimeUnixNano: point.Time().UnixNano(), AsDouble: number})
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var request exportRequest
+	for _, key := range keys {
+		group := groups[key]
+		if len(group.metrics) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(group.metrics))
+		for name := range group.metrics {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		metrics := make([]metric, 0, len(names))
+		for _, name := range names {
+			metrics = append(metrics, *group.metrics[name])
+		}
+		request.ResourceMetrics = append(request.ResourceMetrics, resourceMetrics{
+			Resource:     group.resource,
+			ScopeMetrics: []scopeMetrics{{Scope: scope{Name: scopeName}, Metrics: metrics}},
+		})
+	}
+	return request
+}
+
+func sortAttributes(attributes []keyValue) {
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Key < attributes[j].Key
+	})
+}
+
+func attributesKey(attributes []keyValue) string {
+	var key strings.Builder
+	for _, a := range attributes {
+		key.WriteString(a.Key)
+		key.WriteByte(0)
+		key.WriteString(a.Value.StringValue)
+		key.WriteByte(0)
+	}
+	return key.String()
+}
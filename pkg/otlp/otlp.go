@@ -0,0 +1,157 @@
+// Package otlp writes points as gauges to OpenTelemetry OTLP/HTTP metrics endpoints, e.g. of an OpenTelemetry Collector.
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/httpclient"
+	"github.com/max-bytes/metrics-sender/pkg/sinkutil"
+)
+
+// Client writes points as ExportMetricsServiceRequest, encoded as protobuf or json
+type Client struct {
+	url       string
+	json      bool
+	gzip      bool
+	converter converter
+	headers   http.Header
+	retry     config.ConfigurationRetry
+	batch     config.ConfigurationBatch
+	http      *http.Client
+}
+
+func NewClient(cfg config.ConfigurationOTLP) (*Client, error) {
+	metricsURL, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse otlp url %s: %v", cfg.URL, err)
+	}
+	if metricsURL.Scheme != "http" && metricsURL.Scheme != "https" {
+		return nil, fmt.Errorf("Unsupported protocol scheme %s of otlp url %s", metricsURL.Scheme, cfg.URL)
+	}
+	if cfg.Encoding != config.OTLPEncodingProtobuf && cfg.Encoding != config.OTLPEncodingJSON {
+		return nil, fmt.Errorf("Invalid otlp encoding %s, must be one of %s, %s", cfg.Encoding, config.OTLPEncodingProtobuf, config.OTLPEncodingJSON)
+	}
+	if cfg.MetricPrefix == "" {
+		return nil, fmt.Errorf("OTLP metric prefix must not be empty")
+	}
+
+	headers, err := createHeaders(cfg)
+	if err != nil {
+		return nil, err
+	}
+	client, err := httpclient.New(cfg.Connection, cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		url:       metricsURL.String(),
+		json:      cfg.Encoding == config.OTLPEncodingJSON,
+		gzip:      cfg.GZip,
+		converter: converter{prefix: cfg.MetricPrefix, stateMeasurement: cfg.StateMeasurement, resourceAttributes: cfg.ResourceAttributes},
+		headers:   headers,
+		retry:     cfg.Retry,
+		batch:     cfg.Batch,
+		http:      client,
+	}, nil
+}
+
+// createHeaders adds the content type of the configured encoding to the configured headers
+func createHeaders(cfg config.ConfigurationOTLP) (http.Header, error) {
+	headers, err := httpclient.Headers("otlp", cfg.Headers, httpclient.Credentials{
+		BearerToken: cfg.BearerToken,
+		Username:    cfg.Username,
+		Password:    cfg.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Encoding == config.OTLPEncodingJSON {
+		headers.Set("Content-Type", "application/json")
+	} else {
+		headers.Set("Content-Type", "application/x-protobuf")
+	}
+	if cfg.GZip {
+		headers.Set("Content-Encoding", "gzip")
+	}
+	return headers, nil
+}
+
+// Send converts the points into gauges and writes them as ExportMetricsServiceRequests
+func (c *Client) Send(points []*influxdb1.Point) error {
+	return sinkutil.SendBatches(points, c.batch, c.retry, func(points []*influxdb1.Point) ([]byte, error) {
+		request := c.converter.convert(points)
+		if len(request.ResourceMetrics) == 0 {
+			return nil, nil
+		}
+		return c.encode(request)
+	}, c.write)
+}
+
+func (c *Client) encode(request exportRequest) ([]byte, error) {
+	var body []byte
+	if c.json {
+		var err error
+		if body, err = json.Marshal(request); err != nil {
+			return nil, err
+		}
+	} else {
+		body = encodeRequest(request)
+	}
+	if !c.gzip {
+		return body, nil
+	}
+
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write(body); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+// write sends the request, partially rejected data points of a successful response are not reported
+func (c *Client) write(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// errors of protobuf requests are a protobuf google.rpc.Status
+	if resp.StatusCode >= 300 && resp.Header.Get("Content-Type") == "application/x-protobuf" {
+		status, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		if message, ok := decodeStatusMessage(status); ok {
+			status = []byte(message)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(status))
+	}
+	return httpclient.CheckResponse("OTLP", resp)
+}
+
+// Ping checks that the endpoint is reachable, OTLP/HTTP has no health endpoint
+func (c *Client) Ping() error {
+	return httpclient.Ping(c.http, c.url, c.headers, "OTLP")
+}
+
+func (c *Client) Close() error {
+	c.http.CloseIdleConnections()
+	return nil
+}
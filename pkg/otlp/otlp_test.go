@@ -0,0 +1,120 @@
+package otlp
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/sinkutil/sinktest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func testConfig(url string) config.ConfigurationOTLP {
+	return config.ConfigurationOTLP{
+		URL:                url,
+		Encoding:           config.OTLPEncodingJSON,
+		MetricPrefix:       "naemon",
+		StateMeasurement:   "state",
+		ResourceAttributes: map[string]string{"host": "host.name", "customer": "customer", "ciid": "ciid"},
+	}
+}
+
+func TestSendJSON(t *testing.T) {
+	var requests []string
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			var err error
+			body, err = gzip.NewReader(r.Body)
+			assert.Nil(t, err)
+		}
+		content, _ := io.ReadAll(body)
+		requests = append(requests, string(content))
+		headers = r.Header
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := testConfig(server.URL + "/v1/metrics")
+	cfg.GZip = true
+	cfg.BearerToken = "secret"
+	client, err := NewClient(cfg)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	assert.Nil(t, client.Send(sinktest.Points(t)))
+	assert.Equal(t, "Bearer secret", headers.Get("Authorization"))
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Len(t, requests, 1)
+
+	var expected, actual interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"resourceMetrics": [
+		{"resource": {"attributes": [{"key": "customer", "value": {"stringValue": "acme.corp"}}, {"key": "host.name", "value": {"stringValue": "host1"}}]},
+		 "scopeMetrics": [{"scope": {"name": "metrics-sender"}, "metrics": [
+			{"name": "naemon.check.state", "gauge": {"dataPoints": [
+				{"attributes": [{"key": "service", "value": {"stringValue": "disk C:"}}], "timeUnixNano": "1623407324000000000", "asDouble": 2}]}},
+			{"name": "naemon.perfdata", "gauge": {"dataPoints": [
+				{"attributes": [{"key": "label", "value": {"stringValue": "C:\\ used"}}, {"key": "service", "value": {"stringValue": "disk C:"}}, {"key": "uom", "value": {"stringValue": "%"}}], "timeUnixNano": "1623407324000000000", "asDouble": 12.5}]}},
+			{"name": "naemon.perfdata.warn", "gauge": {"dataPoints": [
+				{"attributes": [{"key": "label", "value": {"stringValue": "C:\\ used"}}, {"key": "service", "value": {"stringValue": "disk C:"}}, {"key": "uom", "value": {"stringValue": "%"}}], "timeUnixNano": "1623407324000000000", "asDouble": 80}]}}]}]},
+		{"resource": {"attributes": [{"key": "host.name", "value": {"stringValue": "host2"}}]},
+		 "scopeMetrics": [{"scope": {"name": "metrics-sender"}, "metrics": [
+			{"name": "naemon.check.state", "gauge": {"dataPoints": [{"timeUnixNano": "1623407384000000000", "asDouble": 0}]}}]}]}]}`), &expected))
+	assert.Nil(t, json.Unmarshal([]byte(requests[0]), &actual))
+	assert.Equal(t, expected, actual)
+}
+
+func TestSendProtobuf(t *testing.T) {
+	var requests [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		requests = append(requests, content)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := testConfig(server.URL)
+	cfg.Encoding = config.OTLPEncodingProtobuf
+	cfg.Batch.MaxPoints = 2
+	client, err := NewClient(cfg)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	points := sinktest.Points(t)
+	assert.Nil(t, client.Send(points))
+	assert.Len(t, requests, 2)
+	assert.Equal(t, encodeRequest(client.converter.convert(points[0:2])), requests[0])
+	assert.Equal(t, encodeRequest(client.converter.convert(points[2:3])), requests[1])
+}
+
+func TestSendError(t *testing.T) {
+	// google.rpc.Status{code: 3, message: "invalid data point"}
+	status := protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 3)
+	status = protowire.AppendString(protowire.AppendTag(status, 2, protowire.BytesType), "invalid data point")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(status)
+	}))
+	defer server.Close()
+
+	cfg := testConfig(server.URL)
+	cfg.Encoding = config.OTLPEncodingProtobuf
+	client, err := NewClient(cfg)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	assert.EqualError(t, client.Send(sinktest.Points(t)), "OTLP write failed with status 400 Bad Request: invalid data point")
+	assert.Nil(t, client.Ping())
+
+	cfg.Encoding = "xml"
+	_, err = NewClient(cfg)
+	assert.NotNil(t, err)
+}
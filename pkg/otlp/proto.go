@@ -0,0 +1,101 @@
+package otlp
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// encodeRequest encodes the request as opentelemetry.proto.collector.metrics.v1.ExportMetricsServiceRequest:
+//
+//	message ExportMetricsServiceRequest { repeated ResourceMetrics resource_metrics = 1; }
+//	message ResourceMetrics { Resource resource = 1; repeated ScopeMetrics scope_metrics = 2; }
+//	message Resource { repeated KeyValue attributes = 1; }
+//	message ScopeMetrics { InstrumentationScope scope = 1; repeated Metric metrics = 2; }
+//	message InstrumentationScope { string name = 1; }
+//	message Metric { string name = 1; Gauge gauge = 5; }
+//	message Gauge { repeated NumberDataPoint data_points = 1; }
+//	message NumberDataPoint { fixed64 time_unix_nano = 3; double as_double = 4; repeated KeyValue attributes = 7; }
+//	message KeyValue { string key = 1; AnyValue value = 2; }
+//	message AnyValue { string string_value = 1; }
+func encodeRequest(request exportRequest) []byte {
+	var b []byte
+	for _, rm := range request.ResourceMetrics {
+		b = appendMessage(b, 1, encodeResourceMetrics(rm))
+	}
+	return b
+}
+
+func encodeResourceMetrics(rm resourceMetrics) []byte {
+	var res []byte
+	for _, a := range rm.Resource.Attributes {
+		res = appendMessage(res, 1, encodeKeyValue(a))
+	}
+	b := appendMessage(nil, 1, res)
+	for _, sm := range rm.ScopeMetrics {
+		var s []byte
+		s = appendMessage(s, 1, protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), sm.Scope.Name))
+		for _, m := range sm.Metrics {
+			s = appendMessage(s, 2, encodeMetric(m))
+		}
+		b = appendMessage(b, 2, s)
+	}
+	return b
+}
+
+func encodeMetric(m metric) []byte {
+	var g []byte
+	for _, dp := range m.Gauge.DataPoints {
+		var d []byte
+		d = protowire.AppendTag(d, 3, protowire.Fixed64Type)
+		d = protowire.AppendFixed64(d, uint64(dp.TimeUnixNano))
+		d = protowire.AppendTag(d, 4, protowire.Fixed64Type)
+		d = protowire.AppendFixed64(d, math.Float64bits(dp.AsDouble))
+		for _, a := range dp.Attributes {
+			d = appendMessage(d, 7, encodeKeyValue(a))
+		}
+		g = appendMessage(g, 1, d)
+	}
+	b := protowire.AppendTag(nil, 1, protowire.BytesType)
+	b = protowire.AppendString(b, m.Name)
+	return appendMessage(b, 5, g)
+}
+
+func encodeKeyValue(kv keyValue) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendString(b, kv.Key)
+	var v []byte
+	v = protowire.AppendTag(v, 1, protowire.BytesType)
+	v = protowire.AppendString(v, kv.Value.StringValue)
+	return appendMessage(b, 2, v)
+}
+
+// appendMessage appends an embedded message as field num
+func appendMessage(b []byte, num protowire.Number, message []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, message)
+}
+
+// decodeStatusMessage returns the message of a google.rpc.Status, which is returned by OTLP/HTTP servers on errors:
+//
+//	message Status { int32 code = 1; string message = 2; repeated google.protobuf.Any details = 3; }
+func decodeStatusMessage(b []byte) (string, bool) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return "", false
+		}
+		b = b[n:]
+		if num == 2 && typ == protowire.BytesType {
+			message, n := protowire.ConsumeString(b)
+			return message, n >= 0
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return "", false
+		}
+		b = b[n:]
+	}
+	return "", false
+}
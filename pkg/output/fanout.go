@@ -0,0 +1,173 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/route"
+	"github.com/sirupsen/logrus"
+)
+
+// SendError is returned by Fanout.Send if required outputs did not accept the points
+type SendError struct {
+	Failures []OutputFailure
+}
+
+// OutputFailure is the error of a single output
+type OutputFailure struct {
+	Output string
+	Err    error
+}
+
+func (e *SendError) Error() string {
+	reasons := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		reasons = append(reasons, fmt.Sprintf("output %s: %v", failure.Output, failure.Err))
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// Fanout sends points to all outputs
+// it remembers which outputs accepted or rejected the points of a file, so that retrying the file only sends them to the remaining outputs
+type Fanout struct {
+	outputs []*Output
+	routes  []outputRoute
+	log     logrus.FieldLogger
+
+	mutex sync.Mutex
+	files map[string]*fileState
+}
+
+// fileState is the progress of sending the points of a file
+type fileState struct {
+	// names of the outputs that are done, because they accepted or rejected the points
+	done map[string]bool
+	// permanent failures of required outputs, which are reported until the file is forgotten
+	rejected []OutputFailure
+}
+
+// outputRoute sends the points it matches only to the outputs it contains
+type outputRoute struct {
+	matcher *route.Matcher
+	outputs map[string]bool
+}
+
+func NewFanout(outputs []*Output, routes []config.ConfigurationOutputRoute, log logrus.FieldLogger) (*Fanout, error) {
+	f := &Fanout{outputs: outputs, log: log, files: map[string]*fileState{}}
+	for _, routeCfg := range routes {
+		matcher, err := route.NewMatcher(routeCfg.Match)
+		if err != nil {
+			return nil, err
+		}
+		r := outputRoute{matcher: matcher, outputs: map[string]bool{}}
+		for _, name := range routeCfg.Outputs {
+			r.outputs[name] = true
+		}
+		f.routes = append(f.routes, r)
+	}
+	return f, nil
+}
+
+// split returns the points of each output, according to the first route that matches each point
+// points that match no route are sent to all outputs
+func (f *Fanout) split(points []*influxdb1.Point) map[string][]*influxdb1.Point {
+	outputPoints := make(map[string][]*influxdb1.Point, len(f.outputs))
+	if len(f.routes) == 0 {
+		for _, output := range f.outputs {
+			outputPoints[output.Name] = points
+		}
+		return outputPoints
+	}
+	for _, point := range points {
+		tags := point.Tags()
+		var matched *outputRoute
+		for i := range f.routes {
+			if f.routes[i].matcher.Matches(tags) {
+				matched = &f.routes[i]
+				break
+			}
+		}
+		for _, output := range f.outputs {
+			if matched == nil || matched.outputs[output.Name] {
+				outputPoints[output.Name] = append(outputPoints[output.Name], point)
+			}
+		}
+	}
+	return outputPoints
+}
+
+// Send sends the points of the file to each output that did not accept them yet, as far as the routes allow
+// it returns nil once all required outputs accepted the points, or buffered the points they could not accept;
+// optional outputs are tried once, their failures are only logged
+// a required output that rejected the points is not sent to again, its failure is returned with those of the other outputs,
+// so that the error becomes permanent once the other outputs are done
+// the same file must not be sent concurrently
+func (f *Fanout) Send(file string, points []*influxdb1.Point) error {
+	f.mutex.Lock()
+	state, found := f.files[file]
+	if !found {
+		state = &fileState{done: map[string]bool{}}
+		f.files[file] = state
+	}
+	f.mutex.Unlock()
+
+	var failures []OutputFailure
+	outputPoints := f.split(points)
+	for _, output := range f.outputs {
+		if state.done[output.Name] {
+			continue
+		}
+		points := outputPoints[output.Name]
+		if len(points) == 0 {
+			state.done[output.Name] = true
+			continue
+		}
+		err := output.Send(points)
+		switch {
+		case err == nil:
+		case !output.Required:
+			f.log.Warnf("Could not send points of file %s to optional output %s: %v", file, output.Name, err)
+		case output.Buffer != nil && !IsPermanent(err):
+			failed := FailedPoints(err, points)
+			if bufferErr := output.Buffer.Append(failed); bufferErr != nil {
+				failures = append(failures, OutputFailure{Output: output.Name, Err: fmt.Errorf("%v, and could not buffer points: %v", err, bufferErr)})
+				continue
+			}
+			f.log.Infof("Buffered %d points of file %s for output %s: %v", len(failed), file, output.Name, err)
+		case IsPermanent(err):
+			state.rejected = append(state.rejected, OutputFailure{Output: output.Name, Err: err})
+		default:
+			failures = append(failures, OutputFailure{Output: output.Name, Err: err})
+			continue
+		}
+		state.done[output.Name] = true
+	}
+
+	if failures = append(failures, state.rejected...); len(failures) > 0 {
+		return &SendError{Failures: failures}
+	}
+	f.Forget(file)
+	return nil
+}
+
+// Forget discards which outputs accepted or rejected the points of the file, e.g. because it was moved to the error folder
+func (f *Fanout) Forget(file string) {
+	f.mutex.Lock()
+	delete(f.files, file)
+	f.mutex.Unlock()
+}
+
+// Outputs returns all outputs
+func (f *Fanout) Outputs() []*Output {
+	return f.outputs
+}
+
+// Close closes all outputs
+func (f *Fanout) Close() {
+	for _, output := range f.outputs {
+		output.Close()
+	}
+}
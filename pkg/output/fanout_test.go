@@ -0,0 +1,154 @@
+package output
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/buffer"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/httpclient"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSink returns the configured errors in order, and nil afterwards
+type testSink struct {
+	errs  []error
+	calls int
+}
+
+func (s *testSink) Send(points []*influxdb1.Point) error {
+	s.calls++
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+	return err
+}
+
+func (s *testSink) Ping() error  { return nil }
+func (s *testSink) Close() error { return nil }
+
+func testPoints(t *testing.T) []*influxdb1.Point {
+	point, err := influxdb1.NewPoint("state", map[string]string{"host": "host123"}, map[string]interface{}{"value": 0}, time.Unix(1623407324, 0))
+	assert.Nil(t, err)
+	return []*influxdb1.Point{point}
+}
+
+func TestFanout(t *testing.T) {
+	unavailable := errors.New("unavailable")
+	customer := &testSink{errs: []error{unavailable}}
+	central := &testSink{}
+	optional := &testSink{errs: []error{unavailable}}
+	fanout, err := NewFanout([]*Output{
+		{Sink: customer, Name: "customer", Required: true},
+		{Sink: central, Name: "central", Required: true},
+		{Sink: optional, Name: "optional"},
+	}, nil, logrus.StandardLogger())
+	assert.Nil(t, err)
+
+	err = fanout.Send("perfdata.1", testPoints(t))
+	assert.EqualError(t, err, "output customer: unavailable")
+	assert.False(t, IsPermanent(err))
+
+	// outputs that accepted the points are not sent to again, neither is the optional output that failed
+	assert.Nil(t, fanout.Send("perfdata.1", testPoints(t)))
+	assert.Equal(t, 2, customer.calls)
+	assert.Equal(t, 1, central.calls)
+	assert.Equal(t, 1, optional.calls)
+
+	// the next send of the same file starts from scratch
+	assert.Nil(t, fanout.Send("perfdata.1", testPoints(t)))
+	assert.Equal(t, 2, central.calls)
+}
+
+func TestFanoutPermanentError(t *testing.T) {
+	rejected := &httpclient.WriteError{Backend: "Influx", StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Message: "invalid"}
+	customer := &testSink{errs: []error{rejected, rejected}}
+	central := &testSink{errs: []error{errors.New("unavailable"), errors.New("unavailable")}}
+	fanout, err := NewFanout([]*Output{
+		{Sink: customer, Name: "customer", Required: true},
+		{Sink: central, Name: "central", Required: true},
+	}, nil, logrus.StandardLogger())
+	assert.Nil(t, err)
+
+	// while an output fails transiently, the file is retried, but not sent to the output that rejected it again
+	err = fanout.Send("perfdata.1", testPoints(t))
+	assert.EqualError(t, err, "output central: unavailable; output customer: Influx write failed with status 400 Bad Request: invalid")
+	assert.False(t, IsPermanent(err))
+	err = fanout.Send("perfdata.1", testPoints(t))
+	assert.False(t, IsPermanent(err))
+	assert.Equal(t, 1, customer.calls)
+
+	// once the other outputs accepted the points, the rejection is permanent
+	err = fanout.Send("perfdata.1", testPoints(t))
+	assert.EqualError(t, err, "output customer: Influx write failed with status 400 Bad Request: invalid")
+	assert.True(t, IsPermanent(err))
+	assert.Equal(t, 1, customer.calls)
+	assert.Equal(t, 3, central.calls)
+}
+
+func TestFanoutBuffer(t *testing.T) {
+	buf, err := buffer.Open(t.TempDir(), 0, 0, logrus.StandardLogger())
+	assert.Nil(t, err)
+	sink := &testSink{errs: []error{errors.New("unavailable")}}
+	fanout, err := NewFanout([]*Output{{Sink: sink, Name: "customer", Required: true, Buffer: buf}}, nil, logrus.StandardLogger())
+	assert.Nil(t, err)
+
+	// the points are buffered, so the file is done
+	assert.Nil(t, fanout.Send("perfdata.1", testPoints(t)))
+
+	var drained []*influxdb1.Point
+	assert.Nil(t, buf.Drain(func(points []*influxdb1.Point) error {
+		drained = append(drained, points...)
+		return nil
+	}))
+	assert.Equal(t, testPoints(t)[0].String(), drained[0].String())
+}
+
+// recordingSink records the hosts of the points it was sent
+type recordingSink struct {
+	hosts []string
+}
+
+func (s *recordingSink) Send(points []*influxdb1.Point) error {
+	for _, point := range points {
+		s.hosts = append(s.hosts, point.Tags()["host"])
+	}
+	return nil
+}
+
+func (s *recordingSink) Ping() error  { return nil }
+func (s *recordingSink) Close() error { return nil }
+
+func TestFanoutRoutes(t *testing.T) {
+	customer := &recordingSink{}
+	central := &recordingSink{}
+	fanout, err := NewFanout([]*Output{
+		{Sink: customer, Name: "customer", Required: true},
+		{Sink: central, Name: "central", Required: true},
+	}, []config.ConfigurationOutputRoute{
+		{Match: map[string]config.ConfigurationMatch{"customer": {Exact: "acme"}}, Outputs: []string{"customer", "central"}},
+		{Match: map[string]config.ConfigurationMatch{"customer": {Glob: "*"}}, Outputs: []string{"central"}},
+	}, logrus.StandardLogger())
+	assert.Nil(t, err)
+
+	var points []*influxdb1.Point
+	for host, customer := range map[string]string{"h1": "acme", "h2": "globex", "h3": ""} {
+		tags := map[string]string{"host": host}
+		if customer != "" {
+			tags["customer"] = customer
+		}
+		point, err := influxdb1.NewPoint("state", tags, map[string]interface{}{"value": 0}, time.Unix(1623407324, 0))
+		assert.Nil(t, err)
+		points = append(points, point)
+	}
+
+	assert.Nil(t, fanout.Send("perfdata.1", points))
+	assert.ElementsMatch(t, []string{"h1", "h3"}, customer.hosts)
+	assert.ElementsMatch(t, []string{"h1", "h2", "h3"}, central.hosts)
+}
@@ -0,0 +1,115 @@
+// Package output sends points to the configured backends.
+package output
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/buffer"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/graphite"
+	"github.com/max-bytes/metrics-sender/pkg/influx"
+	"github.com/max-bytes/metrics-sender/pkg/otlp"
+	"github.com/max-bytes/metrics-sender/pkg/prometheus"
+)
+
+// Sink writes points to a backend, batching and retrying according to its own configuration
+type Sink interface {
+	Send(points []*influxdb1.Point) error
+	Ping() error
+	Close() error
+}
+
+// Output is a configured sink
+type Output struct {
+	Sink
+	Name     string
+	Required bool
+	// HealthCheckInterval is the interval in which the sink is pinged, 0 disables the periodic check
+	HealthCheckInterval time.Duration
+	// Buffer keeps the points the sink did not accept, nil if no buffer is configured
+	Buffer *buffer.Buffer
+}
+
+// New creates the sink of the output
+func New(cfg config.ConfigurationOutput) (*Output, error) {
+	output := &Output{Name: cfg.Name, Required: cfg.Required}
+	switch cfg.Type {
+	case config.OutputTypeInflux:
+		client, err := influx.CreateInfluxConnection(cfg.Influx)
+		if err != nil {
+			return nil, fmt.Errorf("Could not create output %s: %v", cfg.Name, err)
+		}
+		router, err := influx.NewRouter(cfg.Influx.Routes)
+		if err != nil {
+			client.Close()
+			return nil, fmt.Errorf("Could not create output %s: %v", cfg.Name, err)
+		}
+		output.Sink = &influxSink{client: client, router: router, config: cfg.Influx}
+		output.HealthCheckInterval = cfg.Influx.HealthCheckIntervalSeconds * time.Second
+	case config.OutputTypePrometheus:
+		client, err := prometheus.NewClient(cfg.Prometheus)
+		if err != nil {
+			return nil, fmt.Errorf("Could not create output %s: %v", cfg.Name, err)
+		}
+		output.Sink = client
+		output.HealthCheckInterval = cfg.Prometheus.HealthCheckIntervalSeconds * time.Second
+	case config.OutputTypeGraphite:
+		client, err := graphite.NewClient(cfg.Graphite)
+		if err != nil {
+			return nil, fmt.Errorf("Could not create output %s: %v", cfg.Name, err)
+		}
+		output.Sink = client
+		output.HealthCheckInterval = cfg.Graphite.HealthCheckIntervalSeconds * time.Second
+	case config.OutputTypeOTLP:
+		client, err := otlp.NewClient(cfg.OTLP)
+		if err != nil {
+			return nil, fmt.Errorf("Could not create output %s: %v", cfg.Name, err)
+		}
+		output.Sink = client
+		output.HealthCheckInterval = cfg.OTLP.HealthCheckIntervalSeconds * time.Second
+	default:
+		return nil, fmt.Errorf("Invalid type %s of output %s", cfg.Type, cfg.Name)
+	}
+	return output, nil
+}
+
+// IsPermanent returns true if the sink rejected the points, so that sending them again would fail again
+// a *SendError is permanent if all of its failures are
+func IsPermanent(err error) bool {
+	var sendErr *SendError
+	if errors.As(err, &sendErr) {
+		for _, failure := range sendErr.Failures {
+			if !IsPermanent(failure.Err) {
+				return false
+			}
+		}
+		return true
+	}
+	return influx.IsPermanent(err)
+}
+
+// FailedPoints returns the points that were not accepted by the sink that returned the error
+func FailedPoints(err error, points []*influxdb1.Point) []*influxdb1.Point {
+	return influx.FailedPoints(err, points)
+}
+
+type influxSink struct {
+	client influx.Client
+	router *influx.Router
+	config config.ConfigurationInflux
+}
+
+func (s *influxSink) Send(points []*influxdb1.Point) error {
+	return influx.Send(points, s.client, s.router, s.config)
+}
+
+func (s *influxSink) Ping() error {
+	return s.client.Ping()
+}
+
+func (s *influxSink) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+)
+
+// the format written by the PNP4Nagios/nagflux perfdata templates, e.g.
+// DATATYPE::SERVICEPERFDATA\tTIMET::$TIMET$\tHOSTNAME::$HOSTNAME$\tSERVICEDESC::$SERVICEDESC$\tSERVICEPERFDATA::$SERVICEPERFDATA$\tSERVICECHECKCOMMAND::$SERVICECHECKCOMMAND$\tHOSTSTATE::$HOSTSTATE$\tHOSTSTATETYPE::$HOSTSTATETYPE$\tSERVICESTATE::$SERVICESTATE$\tSERVICESTATETYPE::$SERVICESTATETYPE$\tSERVICEOUTPUT::$SERVICEOUTPUT$
+// DATATYPE::HOSTPERFDATA\tTIMET::$TIMET$\tHOSTNAME::$HOSTNAME$\tHOSTPERFDATA::$HOSTPERFDATA$\tHOSTCHECKCOMMAND::$HOSTCHECKCOMMAND$\tHOSTSTATE::$HOSTSTATE$\tHOSTSTATETYPE::$HOSTSTATETYPE$\tHOSTOUTPUT::$HOSTOUTPUT$
+const (
+	nagfluxDelimiter = "\t"
+	nagfluxSeparator = "::"
+
+	nagfluxServiceData = "SERVICEPERFDATA"
+	nagfluxHostData    = "HOSTPERFDATA"
+
+	// host check results are stored with this service name, like in the sample host_perfdata_file_template
+	nagfluxHostService = "CI-Alive"
+)
+
+// states are either numeric ($SERVICESTATEID$, $HOSTSTATEID$) or textual ($SERVICESTATE$, $HOSTSTATE$)
+var nagfluxServiceStates = map[string]int{"OK": 0, "WARNING": 1, "CRITICAL": 2, "UNKNOWN": 3}
+var nagfluxHostStates = map[string]int{"UP": 0, "DOWN": 1, "UNREACHABLE": 2}
+
+// nagfluxLineParser parses lines in the nagflux format and maps their keys to the ones of the configured template
+func nagfluxLineParser(template config.ConfigurationTemplate) lineParser {
+	return func(line string, lineNumber int) (map[string]string, map[string]int, *ParseError) {
+		values := make(map[string]string)
+		valueColumns := make(map[string]int)
+
+		tokenStart := 0
+		for _, token := range strings.Split(line, nagfluxDelimiter) {
+			subTokens := strings.SplitN(token, nagfluxSeparator, 2)
+			if len(subTokens) != 2 {
+				return nil, nil, &ParseError{Line: lineNumber, Column: tokenStart + 1, Token: token, Reason: "invalid number of subtokens"}
+			}
+			values[subTokens[0]] = subTokens[1]
+			valueColumns[subTokens[0]] = tokenStart + 1
+			tokenStart += len(token) + len(nagfluxDelimiter)
+		}
+
+		dataType := values["DATATYPE"]
+		var prefix string
+		var states map[string]int
+		switch dataType {
+		case nagfluxServiceData:
+			prefix = "SERVICE"
+			states = nagfluxServiceStates
+		case nagfluxHostData:
+			prefix = "HOST"
+			states = nagfluxHostStates
+		default:
+			return nil, nil, &ParseError{Line: lineNumber, Column: valueColumns["DATATYPE"], Token: "DATATYPE" + nagfluxSeparator + dataType, Reason: "unknown DATATYPE"}
+		}
+
+		fields := make(map[string]string)
+		columns := make(map[string]int)
+		mapKey := func(from string, to string) {
+			if value, found := values[from]; found {
+				fields[to] = value
+				columns[to] = valueColumns[from]
+			}
+		}
+		mapKey("TIMET", template.TimestampKey)
+		mapKey("HOSTNAME", "host")
+		mapKey(prefix+"PERFDATA", template.PerfdataKey)
+		mapKey(prefix+"STATE", template.StateKey)
+		mapKey(prefix+"OUTPUT", "output")
+		if dataType == nagfluxServiceData {
+			mapKey("SERVICEDESC", "service")
+		} else {
+			fields["service"] = nagfluxHostService
+		}
+
+		if state, found := fields[template.StateKey]; found {
+			if stateID, known := states[strings.ToUpper(state)]; known {
+				fields[template.StateKey] = strconv.Itoa(stateID)
+			} else if _, err := strconv.Atoi(state); err != nil {
+				return nil, nil, &ParseError{Line: lineNumber, Column: columns[template.StateKey], Token: prefix + "STATE" + nagfluxSeparator + state, Reason: fmt.Sprintf("unknown %sSTATE", prefix)}
+			}
+		}
+		return fields, columns, nil
+	}
+}
@@ -1,38 +1,103 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
-	"regexp"
 
+	"github.com/max-bytes/metrics-sender/pkg/config"
 	"github.com/max-bytes/metrics-sender/pkg/influx"
 
 	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/sirupsen/logrus"
 )
 
-var tokenRegex = regexp.MustCompile(`(?m)(.*?)::(.*)`)
-var delimiterRegex = regexp.MustCompile(`\!\*\*\!\*\!\*\*\!`)
+// ParseError describes why a line could not be parsed
+// Line and Column are 1-based, Column is the byte offset of the offending token in the line, or 0 if it refers to the whole line
+type ParseError struct {
+	Line   int
+	Column int
+	Token  string
+	Reason string
+}
+
+func (e ParseError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Reason)
+	}
+	return fmt.Sprintf("line %d, column %d: %s (token %q)", e.Line, e.Column, e.Reason, e.Token)
+}
+
+// lineParser splits a line into its key/value pairs, keyed like the configured template
+// additionally, it returns the column of the token of each key, for error reporting
+type lineParser func(line string, lineNumber int) (map[string]string, map[string]int, *ParseError)
+
+// Parser parses the lines of perfdata files
+type Parser struct {
+	parseLine lineParser
+	separator string
+	encoder   *influx.Encoder
+	lenient   bool
+}
+
+// New creates a parser for lines in the given format
+// In strict mode, the first invalid line aborts parsing and is returned as error.
+// In lenient mode, invalid lines are skipped and returned as a list of ParseErrors, together with the points of all valid lines.
+func New(format string, template config.ConfigurationTemplate, encoder config.ConfigurationEncoder, lenient bool, log logrus.FieldLogger) (*Parser, error) {
+	e, err := influx.NewEncoder(template, encoder, log)
+	if err != nil {
+		return nil, err
+	}
+	p := &Parser{
+		separator: template.Separator,
+		encoder:   e,
+		lenient:   lenient,
+	}
+	switch format {
+	case config.FormatTemplate:
+		p.parseLine = templateLineParser(template)
+	case config.FormatNagflux:
+		p.parseLine = nagfluxLineParser(template)
+		p.separator = nagfluxSeparator
+	default:
+		return nil, fmt.Errorf("Unknown format %s", format)
+	}
+	return p, nil
+}
 
-func Parse(lines []string) ([]*influxdb1.Point, error) {
+// Parse parses the lines of a file into points
+func (p *Parser) Parse(lines []string) ([]*influxdb1.Point, []ParseError, error) {
 
 	var pointsInFile = []*influxdb1.Point{}
+	var parseErrors []ParseError
 
 	// process file line-by-line
-	for _, line := range lines {
-		tokens := delimiterRegex.Split(line, -1)
-		fields := make(map[string]string)
-		for _, token := range tokens {
-			subTokens := tokenRegex.FindStringSubmatch(token)
-			if len(subTokens) != 3 {
-				return nil, fmt.Errorf("Could not parse token %s: invalid number of subtokens", token)
+	for i, line := range lines {
+		pointsOfLine, parseErr := p.parseAndEncodeLine(line, i+1)
+		if parseErr != nil {
+			if !p.lenient {
+				return nil, nil, *parseErr
 			}
-			fields[subTokens[1]] = subTokens[2]
+			parseErrors = append(parseErrors, *parseErr)
+			continue
 		}
+		pointsInFile = append(pointsInFile, pointsOfLine...)
+	}
+	return pointsInFile, parseErrors, nil
+}
+
+func (p *Parser) parseAndEncodeLine(line string, lineNumber int) ([]*influxdb1.Point, *ParseError) {
+	fields, columns, parseErr := p.parseLine(line, lineNumber)
+	if parseErr != nil {
+		return nil, parseErr
+	}
 
-		pointsOfLine, err := influx.EncodeInfluxLines(fields)
-		if err != nil {
-			return nil, fmt.Errorf("Could not encode influx line %s: %v", line, err)
+	pointsOfLine, err := p.encoder.EncodeInfluxLines(fields)
+	if err != nil {
+		var fieldErr *influx.FieldError
+		if errors.As(err, &fieldErr) {
+			return nil, &ParseError{Line: lineNumber, Column: columns[fieldErr.Key], Token: fieldErr.Key + p.separator + fieldErr.Value, Reason: fieldErr.Error()}
 		}
-		pointsInFile = append(pointsInFile, pointsOfLine...)
+		return nil, &ParseError{Line: lineNumber, Reason: fmt.Sprintf("Could not encode influx line: %v", err)}
 	}
-	return pointsInFile, nil
+	return pointsOfLine, nil
 }
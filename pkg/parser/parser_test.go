@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/sirupsen/logrus"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var defaultTemplate = config.ConfigurationTemplate{
+	Delimiter:    "!**!*!**!",
+	Separator:    "::",
+	TimestampKey: "timestamp",
+	StateKey:     "state",
+	PerfdataKey:  "perfdata",
+}
+
+func mustNew(t *testing.T, format string, template config.ConfigurationTemplate, lenient bool) *Parser {
+	return mustNewWithEncoder(t, format, template, config.ConfigurationEncoder{}, lenient)
+}
+
+var defaultSchema = config.ConfigurationSchema{
+	Layout:            config.LayoutNarrow,
+	MetricMeasurement: "metric",
+	StateMeasurement:  "state",
+}
+
+func mustNewWithEncoder(t *testing.T, format string, template config.ConfigurationTemplate, encoder config.ConfigurationEncoder, lenient bool) *Parser {
+	// tests only configure the parts of the encoder they cover
+	if encoder.Schema == (config.ConfigurationSchema{}) {
+		encoder.Schema = defaultSchema
+	}
+	p, err := New(format, template, encoder, lenient, logrus.StandardLogger())
+	assert.Nil(t, err)
+	return p
+}
+
+const validLine = "timestamp::1623407324!**!*!**!host::host123!**!*!**!service::CI-Alive!**!*!**!state::0!**!*!**!perfdata::rta=1.948000ms;3000.000000;5000.000000;0.000000 pl=0%;80;100;0!**!*!**!output::PING OK"
+
+func TestParse(t *testing.T) {
+	points, parseErrors, err := mustNew(t, config.FormatTemplate, defaultTemplate, false).Parse([]string{validLine})
+	assert.Nil(t, err)
+	assert.Empty(t, parseErrors)
+	assert.Len(t, points, 3) // rta, pl and state
+}
+
+func TestParseStrict(t *testing.T) {
+	_, _, err := mustNew(t, config.FormatTemplate, defaultTemplate, false).Parse([]string{validLine, "timestamp::1623407324!**!*!**!host::host123!**!*!**!state::invalid"})
+	assert.Equal(t, ParseError{Line: 2, Column: 53, Token: "state::invalid", Reason: `Could not parse state invalid into integer: strconv.Atoi: parsing "invalid": invalid syntax`}, err)
+}
+
+func TestParseLenient(t *testing.T) {
+	points, parseErrors, err := mustNew(t, config.FormatTemplate, defaultTemplate, true).Parse([]string{
+		"timestamp::1623407324!**!*!**!invalid!**!*!**!state::0",
+		validLine,
+		"timestamp::abc!**!*!**!state::0",
+	})
+	assert.Nil(t, err)
+	assert.Len(t, points, 3)
+	assert.Equal(t, []ParseError{
+		{Line: 1, Column: 31, Token: "invalid", Reason: "invalid number of subtokens"},
+		{Line: 3, Column: 1, Token: "timestamp::abc", Reason: `Could not parse timestamp abc into integer: strconv.ParseInt: parsing "abc": invalid syntax`},
+	}, parseErrors)
+}
+
+func TestParseCustomTemplate(t *testing.T) {
+	template := config.ConfigurationTemplate{
+		Delimiter:    "|",
+		Separator:    "=",
+		TimestampKey: "time",
+		StateKey:     "status",
+		PerfdataKey:  "perf",
+	}
+	points, parseErrors, err := mustNew(t, config.FormatTemplate, template, false).Parse([]string{"time=1623407324|host=host123|status=2|perf=rta=1.9ms;3000;5000;0"})
+	assert.Nil(t, err)
+	assert.Empty(t, parseErrors)
+	assert.Len(t, points, 2)
+	assert.Equal(t, "metric,host=host123,label=rta,uom=ms crit=5000,crit_inverted=false,crit_max=5000,crit_min=0,min=0,value=1.9,warn=3000,warn_inverted=false,warn_max=3000,warn_min=0 1623407324000000000", points[0].String())
+	assert.Equal(t, "state,host=host123 value=2i 1623407324000000000", points[1].String())
+}
+
+func TestParseNagflux(t *testing.T) {
+	points, parseErrors, err := mustNew(t, config.FormatNagflux, defaultTemplate, true).Parse([]string{
+		"DATATYPE::SERVICEPERFDATA\tTIMET::1623407324\tHOSTNAME::host123\tSERVICEDESC::ping\tSERVICEPERFDATA::rta=1.9ms;3000;5000;0\tSERVICECHECKCOMMAND::check_ping\tHOSTSTATE::UP\tHOSTSTATETYPE::HARD\tSERVICESTATE::WARNING\tSERVICESTATETYPE::HARD\tSERVICEOUTPUT::PING WARNING",
+		"DATATYPE::HOSTPERFDATA\tTIMET::1623407324\tHOSTNAME::host123\tHOSTPERFDATA::pl=0%;80;100;0\tHOSTCHECKCOMMAND::check_host_alive\tHOSTSTATE::DOWN\tHOSTSTATETYPE::HARD\tHOSTOUTPUT::PING CRITICAL",
+		"DATATYPE::SERVICEPERFDATA\tTIMET::1623407324\tHOSTNAME::host123\tSERVICEDESC::ping\tSERVICESTATE::BROKEN",
+	})
+	assert.Nil(t, err)
+	assert.Len(t, points, 4)
+	assert.Equal(t, "metric,host=host123,label=rta,output=PING\\ WARNING,service=ping,uom=ms crit=5000,crit_inverted=false,crit_max=5000,crit_min=0,min=0,value=1.9,warn=3000,warn_inverted=false,warn_max=3000,warn_min=0 1623407324000000000", points[0].String())
+	assert.Equal(t, "state,host=host123,output=PING\\ WARNING,service=ping value=1i 1623407324000000000", points[1].String())
+	assert.Equal(t, "state,host=host123,output=PING\\ CRITICAL,service=CI-Alive value=1i 1623407324000000000", points[3].String())
+	assert.Equal(t, []ParseError{
+		{Line: 3, Column: 81, Token: "SERVICESTATE::BROKEN", Reason: "unknown SERVICESTATE"},
+	}, parseErrors)
+}
+
+func TestParseThresholdRanges(t *testing.T) {
+	points, _, err := mustNew(t, config.FormatTemplate, defaultTemplate, false).Parse([]string{"timestamp::1623407324!**!*!**!host::host123!**!*!**!state::0!**!*!**!perfdata::temp=25C;~:30;@10:20"})
+	assert.Nil(t, err)
+	assert.Equal(t, "metric,host=host123,label=temp,uom=C crit_inverted=true,crit_max=20,crit_min=10,value=25,warn_inverted=false,warn_max=30 1623407324000000000", points[0].String())
+
+	// a negative simple threshold is no valid range, but is kept as before ranges were supported
+	points, _, err = mustNew(t, config.FormatTemplate, defaultTemplate, false).Parse([]string{"timestamp::1623407324!**!*!**!host::host123!**!*!**!state::0!**!*!**!perfdata::temp=-10C;-5;abc"})
+	assert.Nil(t, err)
+	assert.Equal(t, "metric,host=host123,label=temp,uom=C value=-10,warn=-5 1623407324000000000", points[0].String())
+}
+
+func TestParseUnitNormalization(t *testing.T) {
+	encoder := config.ConfigurationEncoder{
+		UnitNormalization: config.ConfigurationUnitNormalization{
+			Enabled: true,
+			Rules:   []config.ConfigurationUnitNormalizationRule{{Service: "^raw-", Enabled: false}},
+		},
+	}
+	points, _, err := mustNewWithEncoder(t, config.FormatTemplate, defaultTemplate, encoder, false).Parse([]string{
+		"timestamp::1623407324!**!*!**!host::host123!**!*!**!service::disk!**!*!**!state::0!**!*!**!perfdata::used=2MB;1:3;;0;4 rta=1500ms pl=5%",
+		"timestamp::1623407324!**!*!**!host::host123!**!*!**!service::raw-disk!**!*!**!state::0!**!*!**!perfdata::used=2MB",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "metric,host=host123,label=used,original_uom=MB,service=disk,uom=B max=4194304,min=0,value=2097152,warn_inverted=false,warn_max=3145728,warn_min=1048576 1623407324000000000", points[0].String())
+	assert.Equal(t, "metric,host=host123,label=rta,original_uom=ms,service=disk,uom=s value=1.5 1623407324000000000", points[1].String())
+	assert.Equal(t, "metric,host=host123,label=pl,service=disk,uom=% value=5 1623407324000000000", points[2].String())
+	assert.Equal(t, "metric,host=host123,label=used,service=raw-disk,uom=MB value=2 1623407324000000000", points[4].String())
+}
+
+func TestParseFieldKeys(t *testing.T) {
+	line := "timestamp::1623407324!**!*!**!host::host123!**!*!**!state::0!**!*!**!perfdata::pl=0%!**!*!**!output::PING OK"
+
+	points, _, err := mustNewWithEncoder(t, config.FormatTemplate, defaultTemplate, config.ConfigurationEncoder{FieldKeys: []string{"output"}}, false).Parse([]string{line})
+	assert.Nil(t, err)
+	assert.Len(t, points, 2)
+	assert.Equal(t, "metric,host=host123,label=pl,uom=% value=0 1623407324000000000", points[0].String())
+	assert.Equal(t, `state,host=host123 output="PING OK",value=0i 1623407324000000000`, points[1].String())
+
+	points, _, err = mustNewWithEncoder(t, config.FormatTemplate, defaultTemplate, config.ConfigurationEncoder{FieldKeys: []string{"output"}, FieldMeasurement: "check_output"}, false).Parse([]string{line})
+	assert.Nil(t, err)
+	assert.Len(t, points, 3)
+	assert.Equal(t, "state,host=host123 value=0i 1623407324000000000", points[1].String())
+	assert.Equal(t, `check_output,host=host123 output="PING OK" 1623407324000000000`, points[2].String())
+}
+
+func TestParseTagFilter(t *testing.T) {
+	encoder := config.ConfigurationEncoder{
+		Tags: config.ConfigurationTags{
+			Allow:   []string{"host", "ciid", "customer"},
+			Deny:    []string{"customer"},
+			Rename:  map[string]string{"ciid": "ci_id"},
+			Default: config.TagDefaultDeny,
+		},
+	}
+	points, _, err := mustNewWithEncoder(t, config.FormatTemplate, defaultTemplate, encoder, false).Parse([]string{
+		"timestamp::1623407324!**!*!**!host::host123!**!*!**!state::0!**!*!**!ciid::H123!**!*!**!customer::c1!**!*!**!new::x",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "state,ci_id=H123,host=host123 value=0i 1623407324000000000", points[0].String())
+}
+
+func TestParseSchema(t *testing.T) {
+	line := "timestamp::1623407324!**!*!**!host::host123!**!*!**!service::ping!**!*!**!state::0!**!*!**!perfdata::rta=1.9ms;3000 pl=0%"
+
+	encoder := config.ConfigurationEncoder{
+		Schema: config.ConfigurationSchema{
+			Layout:            config.LayoutWide,
+			MetricMeasurement: "{{.service}}",
+			StateMeasurement:  "{{.service}}_state",
+		},
+	}
+	points, _, err := mustNewWithEncoder(t, config.FormatTemplate, defaultTemplate, encoder, false).Parse([]string{line})
+	assert.Nil(t, err)
+	assert.Len(t, points, 2)
+	assert.Equal(t, "ping,host=host123,service=ping pl=0,pl_uom=\"%\",rta=1.9,rta_uom=\"ms\",rta_warn=3000,rta_warn_inverted=false,rta_warn_max=3000,rta_warn_min=0 1623407324000000000", points[0].String())
+	assert.Equal(t, "ping_state,host=host123,service=ping value=0i 1623407324000000000", points[1].String())
+
+	encoder.Schema.Layout = config.LayoutNarrow
+	encoder.Schema.MetricMeasurement = "{{.customer}}"
+	_, parseErrors, err := mustNewWithEncoder(t, config.FormatTemplate, defaultTemplate, encoder, true).Parse([]string{line})
+	assert.Nil(t, err)
+	assert.Equal(t, []ParseError{{Line: 1, Reason: "Could not encode influx line: Measurement name {{.customer}} rendered to an empty string"}}, parseErrors)
+}
@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+)
+
+// templateLineParser parses lines that consist of key/value pairs, as produced by the configured perfdata file template
+func templateLineParser(template config.ConfigurationTemplate) lineParser {
+	delimiter := template.Delimiter
+	separator := template.Separator
+
+	return func(line string, lineNumber int) (map[string]string, map[string]int, *ParseError) {
+		fields := make(map[string]string)
+		columns := make(map[string]int)
+
+		for tokenStart := 0; tokenStart <= len(line); {
+			tokenEnd := strings.Index(line[tokenStart:], delimiter)
+			if tokenEnd < 0 {
+				tokenEnd = len(line)
+			} else {
+				tokenEnd += tokenStart
+			}
+			token := line[tokenStart:tokenEnd]
+
+			separatorIndex := strings.Index(token, separator)
+			if separatorIndex < 0 {
+				return nil, nil, &ParseError{Line: lineNumber, Column: tokenStart + 1, Token: token, Reason: "invalid number of subtokens"}
+			}
+			key := token[:separatorIndex]
+			fields[key] = token[separatorIndex+len(separator):]
+			columns[key] = tokenStart + 1
+
+			tokenStart = tokenEnd + len(delimiter)
+		}
+		return fields, columns, nil
+	}
+}
@@ -0,0 +1,187 @@
+// Package perfdata parses performance data as described in the Nagios plugin development guidelines
+// https://nagios-plugins.org/doc/guidelines.html#AEN200
+//
+//	'label'=value[UOM];[warn];[crit];[min];[max]
+package perfdata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Datum is a single entry of the performance data
+type Datum struct {
+	Label string
+	// Value is only valid if Unknown is false
+	Value float64
+	// Unknown is true if the value is U, which means that the actual value could not be determined
+	Unknown bool
+	UOM     string
+	// thresholds and limits are kept as their raw strings, empty if not present
+	Warn string
+	Crit string
+	Min  string
+	Max  string
+}
+
+// Error describes an entry of the performance data that could not be parsed
+type Error struct {
+	Token  string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("Could not parse perfdata %q: %s", e.Token, e.Reason)
+}
+
+// Parse splits performance data into its entries
+// entries that cannot be parsed are skipped and returned as errors
+func Parse(perfdata string) ([]Datum, []error) {
+	var data []Datum
+	var errs []error
+
+	for pos := skipSpace(perfdata, 0); pos < len(perfdata); pos = skipSpace(perfdata, pos) {
+		start := pos
+		datum, next, err := parseDatum(perfdata, pos)
+		if err != nil {
+			// continue with the next entry after the invalid one
+			next = skipToSpace(perfdata, next)
+			errs = append(errs, &Error{Token: perfdata[start:next], Reason: err.Error()})
+		} else {
+			data = append(data, datum)
+		}
+		pos = next
+	}
+	return data, errs
+}
+
+func parseDatum(s string, pos int) (Datum, int, error) {
+	var datum Datum
+
+	label, pos, err := parseLabel(s, pos)
+	if err != nil {
+		return datum, pos, err
+	}
+	datum.Label = label
+
+	end := skipToSpace(s, pos)
+	parts := strings.Split(s[pos:end], ";")
+	if len(parts) > 5 {
+		return datum, end, fmt.Errorf("too many fields")
+	}
+
+	valueAndUOM := parts[0]
+	if valueAndUOM == "U" {
+		datum.Unknown = true
+	} else {
+		numberEnd := scanNumber(valueAndUOM)
+		if numberEnd == 0 {
+			return datum, end, fmt.Errorf("missing value")
+		}
+		datum.Value, err = ParseFloat(valueAndUOM[:numberEnd])
+		if err != nil {
+			return datum, end, fmt.Errorf("invalid value: %v", err)
+		}
+		datum.UOM = valueAndUOM[numberEnd:]
+		for _, r := range datum.UOM {
+			if !unicode.IsLetter(r) && r != '%' && r != '/' {
+				return datum, end, fmt.Errorf("invalid unit of measurement %s", datum.UOM)
+			}
+		}
+	}
+
+	optional := []*string{&datum.Warn, &datum.Crit, &datum.Min, &datum.Max}
+	for i, part := range parts[1:] {
+		*optional[i] = part
+	}
+
+	return datum, end, nil
+}
+
+// parseLabel parses the label and the following equals sign
+// labels containing spaces, equals signs or quotes must be enclosed in single quotes, a quote inside the label is escaped by doubling it
+func parseLabel(s string, pos int) (string, int, error) {
+	if s[pos] != '\'' {
+		for i := pos; i < len(s); i++ {
+			switch {
+			case s[i] == '=':
+				if i == pos {
+					return "", i, fmt.Errorf("empty label")
+				}
+				return s[pos:i], i + 1, nil
+			case unicode.IsSpace(rune(s[i])):
+				return "", i, fmt.Errorf("missing =")
+			}
+		}
+		return "", len(s), fmt.Errorf("missing =")
+	}
+
+	var label strings.Builder
+	for i := pos + 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			label.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '\'' {
+			label.WriteByte('\'')
+			i++
+			continue
+		}
+		// closing quote
+		if i+1 >= len(s) || s[i+1] != '=' {
+			return "", i + 1, fmt.Errorf("missing = after quoted label")
+		}
+		if label.Len() == 0 {
+			return "", i + 2, fmt.Errorf("empty label")
+		}
+		return label.String(), i + 2, nil
+	}
+	return "", len(s), fmt.Errorf("unterminated quoted label")
+}
+
+// scanNumber returns the length of the numeric prefix of s
+func scanNumber(s string) int {
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9', c == '.', c == ',', c == '-', c == '+':
+			i++
+		case (c == 'e' || c == 'E') && i > 0 && i+1 < len(s) && (s[i+1] >= '0' && s[i+1] <= '9' || s[i+1] == '-' || s[i+1] == '+'):
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// ParseFloat parses a number of the performance data, which may use a comma as decimal separator
+func ParseFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty number")
+	}
+	for _, r := range s {
+		// strconv.ParseFloat would also accept values like NaN and Inf
+		if !(r >= '0' && r <= '9' || strings.ContainsRune(".,-+eE", r)) {
+			return 0, fmt.Errorf("invalid number %s", s)
+		}
+	}
+	return strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+}
+
+func skipSpace(s string, pos int) int {
+	for pos < len(s) && unicode.IsSpace(rune(s[pos])) {
+		pos++
+	}
+	return pos
+}
+
+func skipToSpace(s string, pos int) int {
+	for pos < len(s) && !unicode.IsSpace(rune(s[pos])) {
+		pos++
+	}
+	return pos
+}
@@ -0,0 +1,65 @@
+package perfdata
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	data, errs := Parse("rta=1.948000ms;3000.000000;5000.000000;0.000000 pl=0%;80;100;0")
+	assert.Empty(t, errs)
+	assert.Equal(t, []Datum{
+		{Label: "rta", Value: 1.948, UOM: "ms", Warn: "3000.000000", Crit: "5000.000000", Min: "0.000000"},
+		{Label: "pl", Value: 0, UOM: "%", Warn: "80", Crit: "100", Min: "0"},
+	}, data)
+}
+
+func TestParseQuotedLabels(t *testing.T) {
+	data, errs := Parse(`'C:\ Used Space'=12GB;50;60;0;100 'it''s=odd'=1c  'time'=0,5s`)
+	assert.Empty(t, errs)
+	assert.Equal(t, []Datum{
+		{Label: `C:\ Used Space`, Value: 12, UOM: "GB", Warn: "50", Crit: "60", Min: "0", Max: "100"},
+		{Label: "it's=odd", Value: 1, UOM: "c"},
+		{Label: "time", Value: 0.5, UOM: "s"},
+	}, data)
+}
+
+func TestParseUnknownValue(t *testing.T) {
+	data, errs := Parse("users=U;5;10")
+	assert.Empty(t, errs)
+	assert.Equal(t, []Datum{{Label: "users", Unknown: true, Warn: "5", Crit: "10"}}, data)
+}
+
+func TestParseInvalid(t *testing.T) {
+	data, errs := Parse("a=abc b c=1us 'open=1 d=NaN")
+	assert.Equal(t, []Datum{{Label: "c", Value: 1, UOM: "us"}}, data)
+	assert.Equal(t, []error{
+		&Error{Token: "a=abc", Reason: "missing value"},
+		&Error{Token: "b", Reason: "missing ="},
+		&Error{Token: "'open=1 d=NaN", Reason: "unterminated quoted label"},
+	}, errs)
+}
+
+func TestParseRange(t *testing.T) {
+	inf := math.Inf(1)
+	for input, expected := range map[string]Range{
+		"10":      {Min: 0, Max: 10},
+		"10:":     {Min: 10, Max: inf},
+		"~:10":    {Min: -inf, Max: 10},
+		"10:20":   {Min: 10, Max: 20},
+		"@10:20":  {Min: 10, Max: 20, Inverted: true},
+		"-1,5:0":  {Min: -1.5, Max: 0},
+		"@~:-3.2": {Min: -inf, Max: -3.2, Inverted: true},
+	} {
+		r, err := ParseRange(input)
+		assert.Nil(t, err, input)
+		assert.Equal(t, expected, r, input)
+	}
+
+	for _, input := range []string{"", "@", ":10", "abc", "20:10", "1:x"} {
+		_, err := ParseRange(input)
+		assert.NotNil(t, err, input)
+	}
+}
@@ -0,0 +1,69 @@
+package perfdata
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Range is a threshold range as described in the Nagios plugin development guidelines
+// https://nagios-plugins.org/doc/guidelines.html#THRESHOLDFORMAT
+//
+//	10      alert if < 0 or > 10
+//	10:     alert if < 10
+//	~:10    alert if > 10
+//	10:20   alert if < 10 or > 20
+//	@10:20  alert if >= 10 and <= 20
+type Range struct {
+	// Min is negative infinity if the range has no lower bound
+	Min float64
+	// Max is positive infinity if the range has no upper bound
+	Max float64
+	// Inverted is true if an alert is raised inside the range instead of outside
+	Inverted bool
+}
+
+// ParseRange parses a threshold range
+func ParseRange(s string) (Range, error) {
+	r := Range{Min: 0, Max: math.Inf(1)}
+
+	str := s
+	if strings.HasPrefix(str, "@") {
+		r.Inverted = true
+		str = str[1:]
+	}
+
+	start, end := "", str
+	if colon := strings.Index(str, ":"); colon >= 0 {
+		start, end = str[:colon], str[colon+1:]
+		if start == "" {
+			return r, fmt.Errorf("invalid range %s: missing start", s)
+		}
+	}
+
+	var err error
+	switch start {
+	case "":
+	case "~":
+		r.Min = math.Inf(-1)
+	default:
+		r.Min, err = ParseFloat(start)
+		if err != nil {
+			return r, fmt.Errorf("invalid range %s: %v", s, err)
+		}
+	}
+
+	if end != "" {
+		r.Max, err = ParseFloat(end)
+		if err != nil {
+			return r, fmt.Errorf("invalid range %s: %v", s, err)
+		}
+	} else if !strings.Contains(str, ":") {
+		return r, fmt.Errorf("invalid range %s: missing end", s)
+	}
+
+	if r.Min > r.Max {
+		return r, fmt.Errorf("invalid range %s: start is greater than end", s)
+	}
+	return r, nil
+}
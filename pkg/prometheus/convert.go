@@ -0,0 +1,164 @@
+package prometheus
+
+import (
+	"sort"
+	"strings"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/sinkutil"
+)
+
+type label struct {
+	name  string
+	value string
+}
+
+type sample struct {
+	value     float64
+	timestamp int64 // milliseconds
+}
+
+type timeSeries struct {
+	labels  []label // sorted by name
+	samples []sample
+}
+
+// converter turns points into time series
+type converter struct {
+	prefix           string
+	stateMeasurement string
+}
+
+// tags that are part of the metric name instead of labels
+var nameTags = map[string]bool{"label": true, "uom": true, "original_uom": true}
+
+// convert creates a time series for each numeric field of the points, samples of the same series are merged
+//   - perfdata points with a label tag become <prefix>_perfdata_<label>_<uom>, other fields like warn are added as suffix
+//   - points of the state measurement become <prefix>_check_state
+//   - other points (e.g. of the wide layout) become <prefix>_perfdata_<field>
+//
+// the remaining tags become labels
+func (c *converter) convert(points []*influxdb1.Point) []timeSeries {
+	series := map[string]*timeSeries{}
+	for _, point := range points {
+		fields, err := point.Fields()
+		if err != nil {
+			continue
+		}
+		tags := point.Tags()
+		timestamp := point.Time().UnixNano() / 1e6
+
+		var base string
+		switch {
+		case point.Name() == c.stateMeasurement:
+			base = c.prefix + "_check_state"
+		case tags["label"] != "":
+			base = c.prefix + "_perfdata_" + tags["label"]
+			if uom := tags["uom"]; uom != "" {
+				base += "_" + uomName(uom)
+			}
+		default:
+			base = c.prefix + "_perfdata"
+		}
+
+		labels := make([]label, 0, len(tags)+1)
+		for key, value := range tags {
+			if !nameTags[key] {
+				labels = append(labels, label{name: sanitizeLabelName(key), value: value})
+			}
+		}
+
+		for field, value := range fields {
+			number, ok := sinkutil.ToFloat(value)
+			if !ok {
+				continue
+			}
+			name := base
+			if field != "value" {
+				name += "_" + field
+			}
+			seriesLabels := append([]label{{name: "__name__", value: sanitizeMetricName(name)}}, labels...)
+			sort.Slice(seriesLabels, func(i, j int) bool {
+				return seriesLabels[i].name < seriesLabels[j].name
+			})
+
+			key := seriesKey(seriesLabels)
+			s, found := series[key]
+			if !found {
+				s = &timeSeries{labels: seriesLabels}
+				series[key] = s
+			}
+			s.samples = append(s.samples, sample{value: number, timestamp: timestamp})
+		}
+	}
+
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]timeSeries, 0, len(keys))
+	for _, key := range keys {
+		s := series[key]
+		// samples of a series must be sent in order
+		sort.SliceStable(s.samples, func(i, j int) bool {
+			return s.samples[i].timestamp < s.samples[j].timestamp
+		})
+		result = append(result, *s)
+	}
+	return result
+}
+
+func seriesKey(labels []label) string {
+	var key strings.Builder
+	for _, l := range labels {
+		key.WriteString(l.name)
+		key.WriteByte(0)
+		key.WriteString(l.value)
+		key.WriteByte(0)
+	}
+	return key.String()
+}
+
+func uomName(uom string) string {
+	if uom == "%" {
+		return "percent"
+	}
+	return uom
+}
+
+// sanitizeMetricName replaces characters that are not allowed in metric names: [a-zA-Z_:][a-zA-Z0-9_:]*
+// colons are reserved for recording rules and are replaced as well
+func sanitizeMetricName(name string) string {
+	return sanitize(name)
+}
+
+// sanitizeLabelName replaces characters that are not allowed in label names: [a-zA-Z_][a-zA-Z0-9_]*
+// names starting with __ are reserved
+func sanitizeLabelName(name string) string {
+	name = sanitize(name)
+	if strings.HasPrefix(name, "__") {
+		name = "_" + strings.TrimLeft(name, "_")
+	}
+	return name
+}
+
+func sanitize(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		valid := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9')
+		switch {
+		case valid:
+			b.WriteRune(r)
+		case i == 0 && r >= '0' && r <= '9':
+			b.WriteByte('_')
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+// Package prometheus writes points to prometheus compatible remote write endpoints (Prometheus, Mimir, VictoriaMetrics, ...).
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/golang/snappy"
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/httpclient"
+	"github.com/max-bytes/metrics-sender/pkg/sinkutil"
+)
+
+// Client writes points as snappy compressed protobuf to a remote write endpoint
+type Client struct {
+	url       string
+	converter converter
+	headers   http.Header
+	retry     config.ConfigurationRetry
+	batch     config.ConfigurationBatch
+	http      *http.Client
+}
+
+func NewClient(cfg config.ConfigurationPrometheus) (*Client, error) {
+	writeURL, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse prometheus url %s: %v", cfg.URL, err)
+	}
+	if writeURL.Scheme != "http" && writeURL.Scheme != "https" {
+		return nil, fmt.Errorf("Unsupported protocol scheme %s of prometheus url %s", writeURL.Scheme, cfg.URL)
+	}
+	if cfg.MetricPrefix == "" {
+		return nil, fmt.Errorf("Prometheus metric prefix must not be empty")
+	}
+
+	headers, err := createHeaders(cfg)
+	if err != nil {
+		return nil, err
+	}
+	client, err := httpclient.New(cfg.Connection, cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		url:       writeURL.String(),
+		converter: converter{prefix: sanitizeMetricName(cfg.MetricPrefix), stateMeasurement: cfg.StateMeasurement},
+		headers:   headers,
+		retry:     cfg.Retry,
+		batch:     cfg.Batch,
+		http:      client,
+	}, nil
+}
+
+// createHeaders adds the headers required by the remote write protocol to the configured headers
+func createHeaders(cfg config.ConfigurationPrometheus) (http.Header, error) {
+	headers, err := httpclient.Headers("prometheus", cfg.Headers, httpclient.Credentials{
+		BearerToken: cfg.BearerToken,
+		Username:    cfg.Username,
+		Password:    cfg.Password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	headers.Set("Content-Type", "application/x-protobuf")
+	headers.Set("Content-Encoding", "snappy")
+	headers.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	return headers, nil
+}
+
+// Send converts the points into time series and writes them as snappy compressed WriteRequests
+func (c *Client) Send(points []*influxdb1.Point) error {
+	return sinkutil.SendBatches(points, c.batch, c.retry, func(points []*influxdb1.Point) ([]byte, error) {
+		series := c.converter.convert(points)
+		if len(series) == 0 {
+			return nil, nil
+		}
+		return snappy.Encode(nil, encodeWriteRequest(series)), nil
+	}, c.write)
+}
+
+func (c *Client) write(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return httpclient.CheckResponse("Prometheus", resp)
+}
+
+// Ping checks that the endpoint is reachable, remote write has no health endpoint
+func (c *Client) Ping() error {
+	return httpclient.Ping(c.http, c.url, c.headers, "Prometheus")
+}
+
+func (c *Client) Close() error {
+	c.http.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,144 @@
+package prometheus
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// decodeWriteRequest decodes the series of a WriteRequest, e.g. {__name__="x",host="h"} -> ["1@1623407324000"]
+func decodeWriteRequest(t *testing.T, request []byte) map[string][]string {
+	consumeBytes := func(b []byte) ([]byte, []byte) {
+		_, _, n := protowire.ConsumeTag(b)
+		value, m := protowire.ConsumeBytes(b[n:])
+		assert.True(t, n > 0 && m > 0)
+		return value, b[n+m:]
+	}
+
+	series := map[string][]string{}
+	for len(request) > 0 {
+		var ts []byte
+		ts, request = consumeBytes(request)
+
+		labels := ""
+		var samples []string
+		for len(ts) > 0 {
+			num, _, _ := protowire.ConsumeTag(ts)
+			var field []byte
+			field, ts = consumeBytes(ts)
+			if num == 1 {
+				name, rest := consumeBytes(field)
+				value, _ := consumeBytes(rest)
+				if labels != "" {
+					labels += ","
+				}
+				labels += string(name) + "=\"" + string(value) + "\""
+				continue
+			}
+			_, _, n := protowire.ConsumeTag(field)
+			value, m := protowire.ConsumeFixed64(field[n:])
+			field = field[n+m:]
+			_, _, n = protowire.ConsumeTag(field)
+			timestamp, _ := protowire.ConsumeVarint(field[n:])
+			samples = append(samples, strconv.FormatFloat(math.Float64frombits(value), 'g', -1, 64)+"@"+strconv.FormatUint(timestamp, 10))
+		}
+		series["{"+labels+"}"] = samples
+	}
+	return series
+}
+
+func newPoint(t *testing.T, measurement string, tags map[string]string, fields map[string]interface{}, timestamp int64) *influxdb1.Point {
+	point, err := influxdb1.NewPoint(measurement, tags, fields, time.Unix(timestamp, 0))
+	assert.Nil(t, err)
+	return point
+}
+
+func TestSend(t *testing.T) {
+	var requests []map[string][]string
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		request, err := snappy.Decode(nil, body)
+		assert.Nil(t, err)
+		requests = append(requests, decodeWriteRequest(t, request))
+		headers = r.Header
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(config.ConfigurationPrometheus{URL: server.URL + "/api/v1/write", MetricPrefix: "naemon", StateMeasurement: "state", BearerToken: "secret"})
+	assert.Nil(t, err)
+	defer client.Close()
+
+	tags := map[string]string{"host": "host1", "service": "disk C:", "label": "C:\\ Used Space", "uom": "%", "9lives": "yes"}
+	err = client.Send([]*influxdb1.Point{
+		newPoint(t, "metric", tags, map[string]interface{}{"value": 12.5, "warn": 80.0, "warn_inverted": false}, 1623407384),
+		newPoint(t, "metric", tags, map[string]interface{}{"value": 12.0, "warn": 80.0, "warn_inverted": false}, 1623407324),
+		newPoint(t, "metric", map[string]string{"host": "host1", "label": "pl"}, map[string]interface{}{"unknown": true}, 1623407324),
+		newPoint(t, "state", map[string]string{"host": "host1", "service": "disk C:"}, map[string]interface{}{"value": 2, "output": "DISK CRITICAL"}, 1623407324),
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "Bearer secret", headers.Get("Authorization"))
+	assert.Equal(t, "snappy", headers.Get("Content-Encoding"))
+	assert.Equal(t, "application/x-protobuf", headers.Get("Content-Type"))
+	assert.Equal(t, []map[string][]string{{
+		`{_9lives="yes",__name__="naemon_perfdata_C___Used_Space_percent",host="host1",service="disk C:"}`:      {"12@1623407324000", "12.5@1623407384000"},
+		`{_9lives="yes",__name__="naemon_perfdata_C___Used_Space_percent_warn",host="host1",service="disk C:"}`: {"80@1623407324000", "80@1623407384000"},
+		`{__name__="naemon_check_state",host="host1",service="disk C:"}`:                                        {"2@1623407324000"},
+	}}, requests)
+}
+
+func TestSendBatches(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(config.ConfigurationPrometheus{URL: server.URL, MetricPrefix: "naemon", Batch: config.ConfigurationBatch{MaxPoints: 2}})
+	assert.Nil(t, err)
+	defer client.Close()
+
+	var points []*influxdb1.Point
+	for i := 0; i < 5; i++ {
+		points = append(points, newPoint(t, "state", map[string]string{"host": "host1"}, map[string]interface{}{"value": i}, 1623407324+int64(i)))
+	}
+	assert.Nil(t, client.Send(points))
+	assert.Equal(t, 3, requests)
+}
+
+func TestSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, "out of order sample\n")
+	}))
+	defer server.Close()
+
+	client, err := NewClient(config.ConfigurationPrometheus{URL: server.URL, MetricPrefix: "naemon"})
+	assert.Nil(t, err)
+	defer client.Close()
+
+	err = client.Send([]*influxdb1.Point{newPoint(t, "state", map[string]string{"host": "host1"}, map[string]interface{}{"value": 0}, 1623407324)})
+	assert.EqualError(t, err, "Prometheus write failed with status 400 Bad Request: out of order sample")
+	assert.Nil(t, client.Ping())
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "naemon_perfdata_C___Used_Space", sanitizeMetricName("naemon_perfdata_C:\\ Used Space"))
+	assert.Equal(t, "_1st", sanitizeMetricName("1st"))
+	assert.Equal(t, "ci_id", sanitizeLabelName("ci-id"))
+	assert.Equal(t, "_reserved", sanitizeLabelName("__reserved"))
+	assert.Equal(t, "_", sanitizeLabelName(""))
+}
@@ -0,0 +1,84 @@
+// Package route matches points against the conditions of routing rules.
+package route
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+)
+
+// Matcher matches the tags of a point against the conditions of a route
+type Matcher struct {
+	conditions []condition
+}
+
+type condition struct {
+	key     string
+	matches func(value string) bool
+}
+
+// NewMatcher compiles the conditions, a matcher without conditions matches all points
+func NewMatcher(match map[string]config.ConfigurationMatch) (*Matcher, error) {
+	keys := make([]string, 0, len(match))
+	for key := range match {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	m := &Matcher{}
+	for _, key := range keys {
+		c, err := newCondition(key, match[key])
+		if err != nil {
+			return nil, err
+		}
+		m.conditions = append(m.conditions, c)
+	}
+	return m, nil
+}
+
+func newCondition(key string, match config.ConfigurationMatch) (condition, error) {
+	set := 0
+	for _, value := range []string{match.Exact, match.Glob, match.Regex} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return condition{}, fmt.Errorf("Condition on tag %s must contain exactly one of exact, glob and regex", key)
+	}
+
+	switch {
+	case match.Glob != "":
+		if _, err := path.Match(match.Glob, ""); err != nil {
+			return condition{}, fmt.Errorf("Invalid glob %s of tag %s: %v", match.Glob, key, err)
+		}
+		return condition{key: key, matches: func(value string) bool {
+			matched, _ := path.Match(match.Glob, value)
+			return matched
+		}}, nil
+	case match.Regex != "":
+		regex, err := regexp.Compile(match.Regex)
+		if err != nil {
+			return condition{}, fmt.Errorf("Invalid regex %s of tag %s: %v", match.Regex, key, err)
+		}
+		return condition{key: key, matches: regex.MatchString}, nil
+	default:
+		return condition{key: key, matches: func(value string) bool {
+			return value == match.Exact
+		}}, nil
+	}
+}
+
+// Matches returns true if all conditions match, a condition on a tag the point does not have never matches
+func (m *Matcher) Matches(tags map[string]string) bool {
+	for _, c := range m.conditions {
+		value, found := tags[c.key]
+		if !found || !c.matches(value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package route
+
+import (
+	"testing"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcher(t *testing.T) {
+	m, err := NewMatcher(map[string]config.ConfigurationMatch{
+		"customer": {Glob: "acme-*"},
+		"host":     {Regex: "^web[0-9]+$"},
+		"service":  {Exact: "ping"},
+	})
+	assert.Nil(t, err)
+	assert.True(t, m.Matches(map[string]string{"customer": "acme-eu", "host": "web12", "service": "ping"}))
+	assert.False(t, m.Matches(map[string]string{"customer": "globex", "host": "web12", "service": "ping"}))
+	assert.False(t, m.Matches(map[string]string{"customer": "acme-eu", "host": "db1", "service": "ping"}))
+	assert.False(t, m.Matches(map[string]string{"customer": "acme-eu", "host": "web12", "service": "ping6"}))
+	assert.False(t, m.Matches(map[string]string{"host": "web12", "service": "ping"}))
+
+	all, err := NewMatcher(nil)
+	assert.Nil(t, err)
+	assert.True(t, all.Matches(map[string]string{}))
+
+	_, err = NewMatcher(map[string]config.ConfigurationMatch{"customer": {Exact: "acme", Glob: "acme-*"}})
+	assert.EqualError(t, err, "Condition on tag customer must contain exactly one of exact, glob and regex")
+	_, err = NewMatcher(map[string]config.ConfigurationMatch{"customer": {Regex: "("}})
+	assert.NotNil(t, err)
+	_, err = NewMatcher(map[string]config.ConfigurationMatch{"customer": {Glob: "["}})
+	assert.NotNil(t, err)
+}
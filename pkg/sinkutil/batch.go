@@ -0,0 +1,63 @@
+package sinkutil
+
+import (
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/httpclient"
+)
+
+// SendBatches encodes the points in batches of at most limits.MaxPoints points and writes the payloads with Retry,
+// it stops at the first error
+// batches whose payload is larger than limits.MaxBytes, or that the server rejected as too large (413), are halved until they fit,
+// a single point that is too large is written on its own anyway
+// encode returns an empty payload if the output has nothing to write for the points, e.g. because none of their fields is numeric
+func SendBatches(points []*influxdb1.Point, limits config.ConfigurationBatch, retry config.ConfigurationRetry,
+	encode func(points []*influxdb1.Point) ([]byte, error), write func(payload []byte) error) error {
+	b := batcher{maxBytes: limits.MaxBytes, retry: retry, encode: encode, write: write}
+	for start := 0; start < len(points); {
+		end := len(points)
+		if limits.MaxPoints > 0 && start+limits.MaxPoints < end {
+			end = start + limits.MaxPoints
+		}
+		if err := b.send(points[start:end]); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+type batcher struct {
+	maxBytes int
+	retry    config.ConfigurationRetry
+	encode   func(points []*influxdb1.Point) ([]byte, error)
+	write    func(payload []byte) error
+}
+
+func (b *batcher) send(points []*influxdb1.Point) error {
+	payload, err := b.encode(points)
+	if err != nil {
+		return err
+	}
+	if len(payload) == 0 {
+		return nil
+	}
+	if b.maxBytes > 0 && len(payload) > b.maxBytes && len(points) > 1 {
+		return b.halve(points)
+	}
+	err = Retry(b.retry, func() error {
+		return b.write(payload)
+	})
+	if err != nil && len(points) > 1 && httpclient.IsTooLarge(err) {
+		return b.halve(points)
+	}
+	return err
+}
+
+func (b *batcher) halve(points []*influxdb1.Point) error {
+	half := len(points) / 2
+	if err := b.send(points[:half]); err != nil {
+		return err
+	}
+	return b.send(points[half:])
+}
@@ -0,0 +1,68 @@
+package sinkutil
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendBatches(t *testing.T) {
+	var points []*influxdb1.Point
+	for i := 0; i < 7; i++ {
+		point, err := influxdb1.NewPoint("state", map[string]string{"host": "host1"}, map[string]interface{}{"value": i}, time.Unix(1623407324+int64(i), 0))
+		assert.Nil(t, err)
+		points = append(points, point)
+	}
+	// one byte per point, except for points without host
+	encode := func(points []*influxdb1.Point) ([]byte, error) {
+		var payload strings.Builder
+		for _, point := range points {
+			if point.Tags()["host"] != "" {
+				payload.WriteByte('x')
+			}
+		}
+		return []byte(payload.String()), nil
+	}
+	retry := config.ConfigurationRetry{MaxAttempts: 1}
+
+	// payloads of more than 2 points are too large for the server
+	var sizes []int
+	write := func(payload []byte) error {
+		sizes = append(sizes, len(payload))
+		if len(payload) > 2 {
+			return &httpclient.WriteError{StatusCode: http.StatusRequestEntityTooLarge}
+		}
+		return nil
+	}
+	assert.Nil(t, SendBatches(points, config.ConfigurationBatch{MaxPoints: 4}, retry, encode, write))
+	assert.Equal(t, []int{4, 2, 2, 3, 1, 2}, sizes)
+
+	// payloads larger than maxBytes are halved before they are written
+	sizes = nil
+	assert.Nil(t, SendBatches(points, config.ConfigurationBatch{MaxBytes: 2}, retry, encode, write))
+	assert.Equal(t, []int{1, 2, 2, 2}, sizes)
+
+	// other errors stop sending
+	sizes = nil
+	unavailable := errors.New("unavailable")
+	err := SendBatches(points, config.ConfigurationBatch{}, retry, encode, func(payload []byte) error {
+		sizes = append(sizes, len(payload))
+		return unavailable
+	})
+	assert.Equal(t, unavailable, err)
+	assert.Equal(t, []int{7}, sizes)
+
+	// empty payloads are not written
+	point, err := influxdb1.NewPoint("state", nil, map[string]interface{}{"value": 0}, time.Unix(1623407324, 0))
+	assert.Nil(t, err)
+	sizes = nil
+	assert.Nil(t, SendBatches([]*influxdb1.Point{point}, config.ConfigurationBatch{}, retry, encode, write))
+	assert.Empty(t, sizes)
+}
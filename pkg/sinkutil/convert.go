@@ -0,0 +1,14 @@
+package sinkutil
+
+// ToFloat converts numeric field values, booleans and strings are not numeric
+func ToFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
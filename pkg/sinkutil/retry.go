@@ -0,0 +1,57 @@
+// Package sinkutil contains the parts that the outputs share: retries, batching and the conversion of field values.
+package sinkutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/httpclient"
+)
+
+// sleep can be replaced in tests
+var sleep = time.Sleep
+
+// Retry calls write until it succeeds, fails permanently or the maximum number of attempts is reached
+// between attempts, it waits with exponential backoff and jitter, or as long as the server requested with Retry-After
+func Retry(retry config.ConfigurationRetry, write func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := write()
+		if err == nil || httpclient.IsPermanent(err) {
+			return err
+		}
+		if attempt >= retry.MaxAttempts {
+			if attempt > 1 {
+				return fmt.Errorf("Giving up after %d attempts: %w", attempt, err)
+			}
+			return err
+		}
+		sleep(backoff(retry, attempt, err))
+	}
+}
+
+// backoff returns the delay after the given (failed) attempt
+func backoff(retry config.ConfigurationRetry, attempt int, err error) time.Duration {
+	maxBackoff := retry.MaxBackoffSeconds * time.Second
+
+	var writeErr *httpclient.WriteError
+	if errors.As(err, &writeErr) && writeErr.RetryAfter > 0 {
+		if maxBackoff > 0 && writeErr.RetryAfter > maxBackoff {
+			return maxBackoff
+		}
+		return writeErr.RetryAfter
+	}
+
+	delay := float64(retry.InitialBackoffMilliseconds*time.Millisecond) * math.Pow(retry.Multiplier, float64(attempt-1))
+	if maxBackoff > 0 && delay > float64(maxBackoff) {
+		delay = float64(maxBackoff)
+	}
+	// spread the delay randomly by +/- jitter, so that several workers do not retry at the same time
+	if retry.Jitter > 0 {
+		delay *= 1 + retry.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(delay)
+}
@@ -0,0 +1,79 @@
+package sinkutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/max-bytes/metrics-sender/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	var delays []time.Duration
+	sleep = func(d time.Duration) { delays = append(delays, d) }
+	defer func() { sleep = time.Sleep }()
+
+	statuses := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusNoContent}
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if statuses[requests] == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "7")
+		}
+		w.WriteHeader(statuses[requests])
+		requests++
+	}))
+	defer server.Close()
+	retry := config.ConfigurationRetry{MaxAttempts: 3, InitialBackoffMilliseconds: 100, MaxBackoffSeconds: 10, Multiplier: 2}
+	write := func() error {
+		resp, err := http.Post(server.URL, "text/plain", nil)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		return httpclient.CheckResponse("Influx", resp)
+	}
+
+	assert.Nil(t, Retry(retry, write))
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 7 * time.Second}, delays)
+
+	// permanent errors are not retried
+	statuses = []int{http.StatusBadRequest}
+	requests = 0
+	err := Retry(retry, write)
+	assert.True(t, httpclient.IsPermanent(err))
+	assert.Equal(t, 1, requests)
+
+	// authentication errors are transient, e.g. during a credential rotation
+	statuses = []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}
+	requests = 0
+	err = Retry(retry, write)
+	assert.False(t, httpclient.IsPermanent(err))
+	assert.Equal(t, 3, requests)
+
+	// transient errors are returned after the last attempt
+	statuses = []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusBadGateway}
+	requests = 0
+	err = Retry(retry, write)
+	assert.EqualError(t, err, "Giving up after 3 attempts: Influx write failed with status 502 Bad Gateway: ")
+	assert.False(t, httpclient.IsPermanent(err))
+	assert.Equal(t, 3, requests)
+}
+
+func TestBackoff(t *testing.T) {
+	retry := config.ConfigurationRetry{InitialBackoffMilliseconds: 500, MaxBackoffSeconds: 3, Multiplier: 2}
+	err := &httpclient.WriteError{StatusCode: http.StatusBadGateway}
+	assert.Equal(t, 500*time.Millisecond, backoff(retry, 1, err))
+	assert.Equal(t, 2*time.Second, backoff(retry, 3, err))
+	assert.Equal(t, 3*time.Second, backoff(retry, 4, err))
+	assert.Equal(t, 3*time.Second, backoff(retry, 1, &httpclient.WriteError{StatusCode: http.StatusServiceUnavailable, RetryAfter: time.Minute}))
+
+	retry.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		delay := backoff(retry, 1, err)
+		assert.True(t, delay >= 250*time.Millisecond && delay <= 750*time.Millisecond, delay)
+	}
+}
@@ -0,0 +1,24 @@
+// Package sinktest provides the points that the tests of the outputs send.
+package sinktest
+
+import (
+	"testing"
+	"time"
+
+	influxdb1 "github.com/influxdata/influxdb1-client/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// Points returns a perfdata point with thresholds, the state of its service and the state of a host without customer,
+// the tag values contain characters that need to be sanitized by some outputs
+func Points(t *testing.T) []*influxdb1.Point {
+	metric, err := influxdb1.NewPoint("metric", map[string]string{"customer": "acme.corp", "host": "host1", "service": "disk C:", "label": "C:\\ used", "uom": "%"},
+		map[string]interface{}{"value": 12.5, "warn": 80.0, "warn_inverted": false}, time.Unix(1623407324, 0))
+	assert.Nil(t, err)
+	state, err := influxdb1.NewPoint("state", map[string]string{"customer": "acme.corp", "host": "host1", "service": "disk C:"},
+		map[string]interface{}{"value": 2, "output": "DISK CRITICAL"}, time.Unix(1623407324, 0))
+	assert.Nil(t, err)
+	host, err := influxdb1.NewPoint("state", map[string]string{"host": "host2"}, map[string]interface{}{"value": 0}, time.Unix(1623407384, 0))
+	assert.Nil(t, err)
+	return []*influxdb1.Point{metric, state, host}
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package spool
+
+import (
+	"errors"
+	"os"
+)
+
+// tryLock is only supported on platforms with flock, without a lock a starting instance
+// could not tell whether the files claimed by another instance are stale, and would send them a second time
+func tryLock(lockPath string) (*os.File, bool, error) {
+	return nil, false, errors.New("Claiming files is only supported on linux, macOS and BSD, because it relies on flock")
+}
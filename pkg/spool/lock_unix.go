@@ -0,0 +1,31 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package spool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// tryLock opens (and creates, if necessary) the lock file and tries to acquire an exclusive lock on it without blocking
+// the lock is released when the returned file is closed, or when the process exits
+// an error wrapping fs.ErrNotExist is returned if the folder of the lock file does not exist
+func tryLock(lockPath string) (*os.File, bool, error) {
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, false, fmt.Errorf("Could not open lock file %s: %w", lockPath, err)
+	}
+	err = unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+	if err != nil {
+		f.Close()
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("Could not lock file %s: %v", lockPath, err)
+	}
+	return f, true, nil
+}
@@ -0,0 +1,140 @@
+package spool
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const errorFileSuffix = ".error"
+
+// Quarantine keeps files that could not be processed in a separate folder, so that they are not retried forever
+// next to each file, a sidecar file with the suffix .error contains the reason and the time of the quarantine
+type Quarantine struct {
+	folder   string
+	maxFiles int
+	maxAge   time.Duration
+
+	mutex sync.Mutex
+}
+
+// NewQuarantine creates the quarantine folder, if necessary
+// maxFiles and maxAge limit the number and the age of the quarantined files, 0 means unlimited
+func NewQuarantine(folder string, maxFiles int, maxAge time.Duration) (*Quarantine, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("Could not create error folder %s: %v", folder, err)
+	}
+	return &Quarantine{folder: folder, maxFiles: maxFiles, maxAge: maxAge}, nil
+}
+
+// Put moves the file into the quarantine folder and writes the reason into its sidecar file
+func (q *Quarantine) Put(path string, reason error) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := time.Now()
+	target := filepath.Join(q.folder, filepath.Base(path))
+	if _, err := os.Stat(target); err == nil {
+		target = fmt.Sprintf("%s.%d", target, now.UnixNano())
+	}
+
+	err := moveFile(path, target)
+	if err != nil {
+		return fmt.Errorf("Could not move file %s into error folder: %v", path, err)
+	}
+
+	content := fmt.Sprintf("time: %s\nreason: %v\n", now.Format(time.RFC3339), reason)
+	err = os.WriteFile(target+errorFileSuffix, []byte(content), 0644)
+	if err != nil {
+		return fmt.Errorf("Could not write error file of %s: %v", target, err)
+	}
+
+	return q.prune(now)
+}
+
+// prune removes the oldest quarantined files that exceed the configured limits
+func (q *Quarantine) prune(now time.Time) error {
+	if q.maxFiles <= 0 && q.maxAge <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(q.folder)
+	if err != nil {
+		return fmt.Errorf("Could not read error folder %s: %v", q.folder, err)
+	}
+
+	// the modification time of the sidecar file is the time of the quarantine
+	errorFiles := make([]fs.FileInfo, 0, len(entries)/2)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), errorFileSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		errorFiles = append(errorFiles, info)
+	}
+
+	// newest first
+	sort.Slice(errorFiles, func(i, j int) bool {
+		return errorFiles[i].ModTime().After(errorFiles[j].ModTime())
+	})
+
+	for i, errorFile := range errorFiles {
+		tooMany := q.maxFiles > 0 && i >= q.maxFiles
+		tooOld := q.maxAge > 0 && now.Sub(errorFile.ModTime()) > q.maxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		errorPath := filepath.Join(q.folder, errorFile.Name())
+		err = os.Remove(strings.TrimSuffix(errorPath, errorFileSuffix))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		err = os.Remove(errorPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveFile renames the file, falling back to copying it if the target is on a different file system
+func moveFile(source string, target string) error {
+	err := os.Rename(source, target)
+	if err == nil {
+		return nil
+	}
+	var linkErr *os.LinkError
+	if !errors.As(err, &linkErr) {
+		return err
+	}
+
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(target)
+		return err
+	}
+	return os.Remove(source)
+}
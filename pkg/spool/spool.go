@@ -0,0 +1,234 @@
+package spool
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ProcessingFolderName is the name of the subfolder of the source folder that contains the claimed files
+	ProcessingFolderName = "processing"
+	lockFileName         = ".lock"
+)
+
+// Spool hands out the files of a source folder to a single instance of metrics-sender
+// a file is claimed by renaming it into a subfolder that belongs to this instance (processing/<instance>),
+// which is atomic and makes it possible for several instances to share the same source folder
+type Spool struct {
+	folder      string
+	claimFolder string
+	lock        *os.File
+
+	attemptsMutex sync.Mutex
+	attempts      map[string]int
+}
+
+// Open prepares the claim folder of this instance inside the source folder
+// files that were claimed by instances that are not running anymore are moved back into the source folder first
+func Open(folder string, log logrus.FieldLogger) (*Spool, error) {
+	processingFolder := filepath.Join(folder, ProcessingFolderName)
+	err := os.MkdirAll(processingFolder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("Could not create processing folder %s: %v", processingFolder, err)
+	}
+
+	err = recoverClaimFolders(folder, processingFolder, log)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	claimFolder := filepath.Join(processingFolder, fmt.Sprintf("%s-%d", hostname, os.Getpid()))
+
+	// another instance that starts up at the same time could consider our claim folder stale and remove it
+	// before we were able to lock it, so we retry if the lock does not refer to the existing lock file anymore
+	for attempt := 0; attempt < 3; attempt++ {
+		err = os.MkdirAll(claimFolder, 0755)
+		if err != nil {
+			return nil, fmt.Errorf("Could not create claim folder %s: %v", claimFolder, err)
+		}
+		lock, locked, err := tryLock(filepath.Join(claimFolder, lockFileName))
+		if errors.Is(err, fs.ErrNotExist) || (err == nil && !locked) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if stillLinked(lock) {
+			return &Spool{folder: folder, claimFolder: claimFolder, lock: lock, attempts: map[string]int{}}, nil
+		}
+		lock.Close()
+	}
+	return nil, fmt.Errorf("Could not lock claim folder %s", claimFolder)
+}
+
+// Folder returns the source folder
+func (s *Spool) Folder() string {
+	return s.folder
+}
+
+// Claimed lists the files that are currently claimed by this instance, e.g. because sending them failed before
+func (s *Spool) Claimed() ([]fs.DirEntry, error) {
+	return listFiles(s.claimFolder)
+}
+
+// Claim moves the file with the given name from the source folder into the claim folder of this instance
+// and returns its new path. If the file is already claimed by this instance, its path is returned as well.
+// An error wrapping fs.ErrNotExist is returned if the file does not exist (anymore), e.g. because another instance claimed it.
+func (s *Spool) Claim(name string) (string, error) {
+	claimedPath := filepath.Join(s.claimFolder, name)
+	if _, err := os.Stat(claimedPath); err == nil {
+		return claimedPath, nil
+	}
+	err := os.Rename(filepath.Join(s.folder, name), claimedPath)
+	if err != nil {
+		return "", err
+	}
+	return claimedPath, nil
+}
+
+// Complete deletes a claimed file after it has been processed successfully
+func (s *Spool) Complete(name string) error {
+	s.forgetAttempts(name)
+	return os.Remove(filepath.Join(s.claimFolder, name))
+}
+
+// Failed records a failed attempt to process a claimed file and returns the number of failed attempts so far
+func (s *Spool) Failed(name string) int {
+	s.attemptsMutex.Lock()
+	defer s.attemptsMutex.Unlock()
+	s.attempts[name]++
+	return s.attempts[name]
+}
+
+// Quarantine moves a claimed file that cannot be processed into the quarantine
+func (s *Spool) Quarantine(name string, q *Quarantine, reason error) error {
+	s.forgetAttempts(name)
+	return q.Put(filepath.Join(s.claimFolder, name), reason)
+}
+
+func (s *Spool) forgetAttempts(name string) {
+	s.attemptsMutex.Lock()
+	defer s.attemptsMutex.Unlock()
+	delete(s.attempts, name)
+}
+
+// Close moves all claimed files back into the source folder and removes the claim folder
+func (s *Spool) Close() error {
+	err := releaseClaimFolder(s.folder, s.claimFolder)
+	s.lock.Close()
+	return err
+}
+
+// recoverClaimFolders releases the claim folders of all instances that do not hold the lock on their folder anymore
+func recoverClaimFolders(folder string, processingFolder string, log logrus.FieldLogger) error {
+	entries, err := os.ReadDir(processingFolder)
+	if err != nil {
+		return fmt.Errorf("Could not read processing folder %s: %v", processingFolder, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		claimFolder := filepath.Join(processingFolder, entry.Name())
+		lock, locked, err := tryLock(filepath.Join(claimFolder, lockFileName))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // already recovered by another instance that started at the same time
+		}
+		if err != nil {
+			return err
+		}
+		if !locked {
+			continue // instance is still running
+		}
+		log.Infof("Recovering files claimed by instance %s", entry.Name())
+		err = releaseClaimFolder(folder, claimFolder)
+		lock.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseClaimFolder moves all files of a claim folder back into the source folder and removes the claim folder
+// files or folders that are gone were released by another instance at the same time
+func releaseClaimFolder(folder string, claimFolder string) error {
+	files, err := listFiles(claimFolder)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Could not read claim folder %s: %v", claimFolder, err)
+	}
+	for _, file := range files {
+		err = os.Rename(filepath.Join(claimFolder, file.Name()), recoveredPath(folder, file.Name()))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("Could not move claimed file %s back into source folder: %v", file.Name(), err)
+		}
+	}
+	err = os.Remove(filepath.Join(claimFolder, lockFileName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	err = os.Remove(claimFolder)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// recoveredPath returns the path in the source folder that a released file is moved to
+// it never overwrites a file with the same name that has been written in the meantime, but appends .recovered, .recovered-2, ...
+func recoveredPath(folder string, name string) string {
+	target := filepath.Join(folder, name)
+	for i := 1; ; i++ {
+		if _, err := os.Lstat(target); err != nil {
+			return target // usually fs.ErrNotExist, other errors are reported by the rename
+		}
+		if i == 1 {
+			target = filepath.Join(folder, name+".recovered")
+		} else {
+			target = filepath.Join(folder, fmt.Sprintf("%s.recovered-%d", name, i))
+		}
+	}
+}
+
+// listFiles returns all regular files of a folder, except the lock file
+func listFiles(folder string) ([]fs.DirEntry, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), lockFileName) {
+			continue
+		}
+		files = append(files, entry)
+	}
+	return files, nil
+}
+
+// stillLinked returns true if the locked file is still the one that exists at its path
+func stillLinked(lock *os.File) bool {
+	lockInfo, err := lock.Stat()
+	if err != nil {
+		return false
+	}
+	pathInfo, err := os.Stat(lock.Name())
+	if err != nil {
+		return false
+	}
+	return os.SameFile(lockInfo, pathInfo)
+}
@@ -0,0 +1,136 @@
+package spool
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimAndComplete(t *testing.T) {
+	folder := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(folder, "perfdata.1"), []byte("line"), 0644))
+
+	s, err := Open(folder, logrus.StandardLogger())
+	assert.Nil(t, err)
+
+	claimedPath, err := s.Claim("perfdata.1")
+	assert.Nil(t, err)
+	assert.NoFileExists(t, filepath.Join(folder, "perfdata.1"))
+	assert.FileExists(t, claimedPath)
+
+	// claiming again returns the already claimed file
+	claimedAgain, err := s.Claim("perfdata.1")
+	assert.Nil(t, err)
+	assert.Equal(t, claimedPath, claimedAgain)
+
+	_, err = s.Claim("perfdata.2")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	claimed, err := s.Claimed()
+	assert.Nil(t, err)
+	assert.Len(t, claimed, 1)
+
+	assert.Nil(t, s.Complete("perfdata.1"))
+	assert.NoFileExists(t, claimedPath)
+	assert.Nil(t, s.Close())
+}
+
+func TestRecoverStaleClaims(t *testing.T) {
+	folder := t.TempDir()
+	staleFolder := filepath.Join(folder, ProcessingFolderName, "otherhost-123")
+	assert.Nil(t, os.MkdirAll(staleFolder, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(staleFolder, "perfdata.1"), []byte("line"), 0644))
+
+	s, err := Open(folder, logrus.StandardLogger())
+	assert.Nil(t, err)
+	defer s.Close()
+
+	assert.FileExists(t, filepath.Join(folder, "perfdata.1"))
+	assert.NoDirExists(t, staleFolder)
+}
+
+func TestRecoverWithoutOverwriting(t *testing.T) {
+	folder := t.TempDir()
+	staleFolder := filepath.Join(folder, ProcessingFolderName, "otherhost-123")
+	assert.Nil(t, os.MkdirAll(staleFolder, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(staleFolder, "perfdata.1"), []byte("stale"), 0644))
+	// written in the meantime, and recovered before
+	assert.Nil(t, os.WriteFile(filepath.Join(folder, "perfdata.1"), []byte("new"), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(folder, "perfdata.1.recovered"), []byte("recovered"), 0644))
+
+	s, err := Open(folder, logrus.StandardLogger())
+	assert.Nil(t, err)
+	defer s.Close()
+
+	for name, content := range map[string]string{"perfdata.1": "new", "perfdata.1.recovered": "recovered", "perfdata.1.recovered-2": "stale"} {
+		actual, err := os.ReadFile(filepath.Join(folder, name))
+		assert.Nil(t, err)
+		assert.Equal(t, content, string(actual))
+	}
+}
+
+func TestRecoveredByOtherInstance(t *testing.T) {
+	// another instance that starts at the same time removed the stale claim folder already
+	missing := filepath.Join(t.TempDir(), ProcessingFolderName, "otherhost-123")
+	_, _, err := tryLock(filepath.Join(missing, lockFileName))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.Nil(t, releaseClaimFolder(t.TempDir(), missing))
+}
+
+func TestKeepClaimsOfRunningInstance(t *testing.T) {
+	folder := t.TempDir()
+
+	running, err := Open(folder, logrus.StandardLogger())
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(filepath.Join(folder, "perfdata.1"), []byte("line"), 0644))
+	claimedPath, err := running.Claim("perfdata.1")
+	assert.Nil(t, err)
+
+	// simulate a second instance by moving the claim folder of the first one, which keeps its lock
+	otherClaimFolder := filepath.Join(folder, ProcessingFolderName, "otherhost-123")
+	assert.Nil(t, os.Rename(filepath.Dir(claimedPath), otherClaimFolder))
+
+	second, err := Open(folder, logrus.StandardLogger())
+	assert.Nil(t, err)
+	defer second.Close()
+
+	assert.NoFileExists(t, filepath.Join(folder, "perfdata.1"))
+	assert.FileExists(t, filepath.Join(otherClaimFolder, "perfdata.1"))
+}
+
+func TestQuarantine(t *testing.T) {
+	folder := t.TempDir()
+	errorFolder := filepath.Join(t.TempDir(), "errors")
+	q, err := NewQuarantine(errorFolder, 2, 0)
+	assert.Nil(t, err)
+
+	s, err := Open(folder, logrus.StandardLogger())
+	assert.Nil(t, err)
+	defer s.Close()
+
+	for i, name := range []string{"perfdata.1", "perfdata.2", "perfdata.3"} {
+		assert.Nil(t, os.WriteFile(filepath.Join(folder, name), []byte("line"), 0644))
+		_, err = s.Claim(name)
+		assert.Nil(t, err)
+		assert.Nil(t, s.Quarantine(name, q, errors.New("invalid line")))
+		// make sure the sidecar files have different modification times
+		oldTime := time.Now().Add(-time.Hour * time.Duration(3-i))
+		assert.Nil(t, os.Chtimes(filepath.Join(errorFolder, name+errorFileSuffix), oldTime, oldTime))
+	}
+
+	content, err := os.ReadFile(filepath.Join(errorFolder, "perfdata.3.error"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), "reason: invalid line")
+
+	// only the newest two files are kept
+	entries, err := os.ReadDir(errorFolder)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 4)
+	assert.NoFileExists(t, filepath.Join(errorFolder, "perfdata.1"))
+}
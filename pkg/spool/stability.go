@@ -0,0 +1,100 @@
+// Package spool manages the perfdata files inside a source folder.
+package spool
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+)
+
+// StabilityChecker decides whether a file has been completely written and can be processed
+type StabilityChecker struct {
+	minAge            time.Duration
+	checkSize         bool
+	sizeCheckInterval time.Duration
+	ignore            *regexp.Regexp
+
+	mutex        sync.Mutex
+	observations map[string]observation
+}
+
+type observation struct {
+	size       int64
+	modTime    time.Time
+	observedAt time.Time
+}
+
+func NewStabilityChecker(cfg config.ConfigurationStability) (*StabilityChecker, error) {
+	var ignore *regexp.Regexp
+	if cfg.IgnorePattern != "" {
+		var err error
+		ignore, err = regexp.Compile(cfg.IgnorePattern)
+		if err != nil {
+			return nil, fmt.Errorf("Could not compile ignore pattern %s: %v", cfg.IgnorePattern, err)
+		}
+	}
+	return &StabilityChecker{
+		minAge:            cfg.MinAgeSeconds * time.Second,
+		checkSize:         cfg.CheckSize,
+		sizeCheckInterval: cfg.SizeCheckIntervalSeconds * time.Second,
+		ignore:            ignore,
+		observations:      map[string]observation{},
+	}, nil
+}
+
+// Ignored returns true if the file name matches the ignore pattern, e.g. because it is a temporary file
+func (c *StabilityChecker) Ignored(name string) bool {
+	return c.ignore != nil && c.ignore.MatchString(name)
+}
+
+// Check returns true if the file is stable
+// if it is not, it also returns the duration after which it makes sense to check the file again
+func (c *StabilityChecker) Check(name string, info fs.FileInfo, now time.Time) (bool, time.Duration) {
+	if age := now.Sub(info.ModTime()); age < c.minAge {
+		return false, c.minAge - age
+	}
+
+	if !c.checkSize {
+		return true, 0
+	}
+
+	// the size (and modification time) must be unchanged across two observations that are at least sizeCheckInterval apart
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	previous, found := c.observations[name]
+	if found && previous.size == info.Size() && previous.modTime.Equal(info.ModTime()) {
+		if elapsed := now.Sub(previous.observedAt); elapsed < c.sizeCheckInterval {
+			return false, c.sizeCheckInterval - elapsed
+		}
+		return true, 0
+	}
+	c.observations[name] = observation{size: info.Size(), modTime: info.ModTime(), observedAt: now}
+	return false, c.sizeCheckInterval
+}
+
+// Forget removes all observations of the file, must be called after the file has been processed or is gone
+func (c *StabilityChecker) Forget(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.observations, name)
+}
+
+// Retain removes the observations of all files except the given ones, which are the files a scan of the folder found
+// it forgets files that disappeared without being processed, e.g. because they were deleted or claimed by another instance
+func (c *StabilityChecker) Retain(names []string) {
+	present := make(map[string]bool, len(names))
+	for _, name := range names {
+		present[name] = true
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for name := range c.observations {
+		if !present[name] {
+			delete(c.observations, name)
+		}
+	}
+}
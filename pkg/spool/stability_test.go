@@ -0,0 +1,102 @@
+package spool
+
+import (
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/max-bytes/metrics-sender/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type fileInfo struct {
+	size    int64
+	modTime time.Time
+}
+
+func (f fileInfo) Name() string       { return "perfdata" }
+func (f fileInfo) Size() int64        { return f.size }
+func (f fileInfo) Mode() fs.FileMode  { return 0644 }
+func (f fileInfo) ModTime() time.Time { return f.modTime }
+func (f fileInfo) IsDir() bool        { return false }
+func (f fileInfo) Sys() interface{}   { return nil }
+
+func TestStabilityMinAge(t *testing.T) {
+	checker, err := NewStabilityChecker(config.ConfigurationStability{MinAgeSeconds: 10})
+	assert.Nil(t, err)
+	now := time.Unix(1623407324, 0)
+
+	stable, retryAfter := checker.Check("perfdata", fileInfo{size: 1, modTime: now.Add(-4 * time.Second)}, now)
+	assert.False(t, stable)
+	assert.Equal(t, 6*time.Second, retryAfter)
+
+	stable, retryAfter = checker.Check("perfdata", fileInfo{size: 1, modTime: now.Add(-10 * time.Second)}, now)
+	assert.True(t, stable)
+	assert.Equal(t, time.Duration(0), retryAfter)
+}
+
+func TestStabilitySize(t *testing.T) {
+	checker, err := NewStabilityChecker(config.ConfigurationStability{CheckSize: true, SizeCheckIntervalSeconds: 2})
+	assert.Nil(t, err)
+	now := time.Unix(1623407324, 0)
+	info := fileInfo{size: 100, modTime: now}
+
+	// the first observation is never stable
+	stable, retryAfter := checker.Check("perfdata", info, now)
+	assert.False(t, stable)
+	assert.Equal(t, 2*time.Second, retryAfter)
+
+	// unchanged, but observed too early
+	stable, retryAfter = checker.Check("perfdata", info, now.Add(500*time.Millisecond))
+	assert.False(t, stable)
+	assert.Equal(t, 1500*time.Millisecond, retryAfter)
+
+	// changed between the observations, which starts over
+	changed := fileInfo{size: 200, modTime: now.Add(time.Second)}
+	stable, retryAfter = checker.Check("perfdata", changed, now.Add(2*time.Second))
+	assert.False(t, stable)
+	assert.Equal(t, 2*time.Second, retryAfter)
+
+	stable, _ = checker.Check("perfdata", changed, now.Add(4*time.Second))
+	assert.True(t, stable)
+
+	// observations are independent per file
+	stable, _ = checker.Check("other", changed, now.Add(4*time.Second))
+	assert.False(t, stable)
+}
+
+func TestStabilityForget(t *testing.T) {
+	checker, err := NewStabilityChecker(config.ConfigurationStability{CheckSize: true, SizeCheckIntervalSeconds: 1})
+	assert.Nil(t, err)
+	now := time.Unix(1623407324, 0)
+	info := fileInfo{size: 100, modTime: now}
+
+	checker.Check("processed", info, now)
+	checker.Check("deleted", info, now)
+	checker.Check("present", info, now)
+	checker.Forget("processed")
+	assert.Len(t, checker.observations, 2)
+
+	// a scan that no longer sees the deleted file
+	checker.Retain([]string{"present", "new"})
+	assert.Len(t, checker.observations, 1)
+	stable, _ := checker.Check("present", info, now.Add(time.Second))
+	assert.True(t, stable)
+	stable, _ = checker.Check("deleted", info, now.Add(time.Second))
+	assert.False(t, stable)
+}
+
+func TestIgnored(t *testing.T) {
+	checker, err := NewStabilityChecker(config.ConfigurationStability{IgnorePattern: `^\.|\.tmp$`})
+	assert.Nil(t, err)
+	assert.True(t, checker.Ignored(".perfdata"))
+	assert.True(t, checker.Ignored("perfdata.tmp"))
+	assert.False(t, checker.Ignored("perfdata"))
+
+	checker, err = NewStabilityChecker(config.ConfigurationStability{})
+	assert.Nil(t, err)
+	assert.False(t, checker.Ignored(".perfdata"))
+
+	_, err = NewStabilityChecker(config.ConfigurationStability{IgnorePattern: "("})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,37 @@
+// Package watcher notifies about files that have been completely written to a directory.
+package watcher
+
+import "errors"
+
+// ErrOverflow is reported when the kernel dropped events, e.g. because they were not consumed fast enough.
+// After receiving it, the caller should fall back to a full rescan of the directory.
+var ErrOverflow = errors.New("watcher event queue overflowed, events might have been lost")
+
+// ErrRemoved is reported when the watched directory was removed or moved.
+// The watch is gone afterwards and the watcher stops, so the caller has to create a new one or give up.
+var ErrRemoved = errors.New("watched directory was removed or moved")
+
+// Watcher reports the names of files inside a single directory once they are ready to be processed,
+// i.e. after they have been closed after writing or have been moved into the directory.
+type Watcher struct {
+	events chan string
+	errors chan error
+	done   chan struct{}
+	closer func() error
+}
+
+// Events returns the channel on which the names (not full paths) of ready files are sent.
+func (w *Watcher) Events() <-chan string {
+	return w.events
+}
+
+// Errors returns the channel on which errors of the underlying mechanism are sent.
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Close stops watching and releases all resources.
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.closer()
+}
@@ -0,0 +1,95 @@
+//go:build linux
+// +build linux
+
+package watcher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// New starts watching the directory dir using inotify.
+// It reports IN_CLOSE_WRITE and IN_MOVED_TO events, which are the ones that signal a completely written file.
+func New(dir string) (*Watcher, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("Could not initialize inotify: %v", err)
+	}
+	_, err = unix.InotifyAddWatch(fd, dir, unix.IN_CLOSE_WRITE|unix.IN_MOVED_TO|unix.IN_DELETE_SELF|unix.IN_MOVE_SELF|unix.IN_ONLYDIR)
+	if err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("Could not watch directory %s: %v", dir, err)
+	}
+
+	// NOTE: because the file descriptor is non-blocking, os.NewFile registers it with the runtime poller,
+	// which makes it possible to interrupt a pending Read() by closing the file
+	file := os.NewFile(uintptr(fd), dir)
+
+	w := &Watcher{
+		events: make(chan string, 1024),
+		errors: make(chan error, 1),
+		done:   make(chan struct{}),
+		closer: file.Close,
+	}
+	go w.readEvents(file, dir)
+	return w, nil
+}
+
+func (w *Watcher) readEvents(file *os.File, dir string) {
+	defer close(w.events)
+	defer close(w.errors)
+
+	var buf [unix.SizeofInotifyEvent * 256]byte
+	for {
+		n, err := file.Read(buf[:])
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				w.sendError(fmt.Errorf("Could not read inotify events: %v", err))
+			}
+			return
+		}
+
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			event := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + unix.SizeofInotifyEvent
+			nameEnd := nameStart + int(event.Len)
+			offset = nameEnd
+
+			switch {
+			case event.Mask&unix.IN_Q_OVERFLOW != 0:
+				w.sendError(ErrOverflow)
+			case event.Mask&(unix.IN_DELETE_SELF|unix.IN_MOVE_SELF) != 0:
+				// unlike the other errors, it must not get lost behind a pending one
+				select {
+				case w.errors <- fmt.Errorf("%w: %s", ErrRemoved, dir):
+				case <-w.done:
+				}
+				return
+			case event.Mask&unix.IN_ISDIR != 0:
+				// directories are never processed
+			case event.Mask&(unix.IN_CLOSE_WRITE|unix.IN_MOVED_TO) != 0 && event.Len > 0:
+				// the name is padded with null bytes
+				name := string(bytes.TrimRight(buf[nameStart:nameEnd], "\x00"))
+				select {
+				case w.events <- name:
+				case <-w.done:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (w *Watcher) sendError(err error) {
+	select {
+	case w.errors <- err:
+	case <-w.done:
+	default:
+		// an error is already pending, which triggers the same reaction anyway
+	}
+}
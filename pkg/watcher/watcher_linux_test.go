@@ -0,0 +1,101 @@
+//go:build linux
+// +build linux
+
+package watcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nextEvent(t *testing.T, w *Watcher) string {
+	select {
+	case name := <-w.Events():
+		return name
+	case err := <-w.Errors():
+		t.Fatalf("Unexpected error: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("No event received")
+	}
+	return ""
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	// on the same filesystem, so that renaming into dir is a move
+	outside := t.TempDir()
+
+	w, err := New(dir)
+	assert.Nil(t, err)
+
+	// IN_CLOSE_WRITE
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "written"), []byte("a"), 0644))
+	assert.Equal(t, "written", nextEvent(t, w))
+
+	// IN_MOVED_TO
+	assert.Nil(t, os.WriteFile(filepath.Join(outside, "moved"), []byte("b"), 0644))
+	assert.Nil(t, os.Rename(filepath.Join(outside, "moved"), filepath.Join(dir, "moved")))
+	assert.Equal(t, "moved", nextEvent(t, w))
+
+	// a file that is still open for writing is not reported yet
+	f, err := os.Create(filepath.Join(dir, "open"))
+	assert.Nil(t, err)
+	_, err = f.WriteString("c")
+	assert.Nil(t, err)
+
+	// directories are ignored, whether they are created or moved into the folder
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "created"), 0755))
+	assert.Nil(t, os.Mkdir(filepath.Join(outside, "dir"), 0755))
+	assert.Nil(t, os.Rename(filepath.Join(outside, "dir"), filepath.Join(dir, "dir")))
+
+	assert.Nil(t, f.Close())
+	assert.Equal(t, "open", nextEvent(t, w))
+
+	// Close ends readEvents, which closes both channels
+	assert.Nil(t, w.Close())
+	select {
+	case _, ok := <-w.Events():
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("Events were not closed")
+	}
+	select {
+	case _, ok := <-w.Errors():
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("Errors were not closed")
+	}
+}
+
+func TestWatcherMissingDirectory(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing"))
+	assert.NotNil(t, err)
+}
+
+func TestWatcherRemovedDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "spool")
+	assert.Nil(t, os.Mkdir(dir, 0755))
+	w, err := New(dir)
+	assert.Nil(t, err)
+	defer w.Close()
+
+	assert.Nil(t, os.Remove(dir))
+	select {
+	case err := <-w.Errors():
+		assert.True(t, errors.Is(err, ErrRemoved), err)
+	case <-time.After(time.Second):
+		t.Fatal("No error received")
+	}
+	// the watcher stops
+	select {
+	case _, ok := <-w.Events():
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("Events were not closed")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package watcher
+
+import "errors"
+
+// New is only supported on linux, where inotify is available.
+func New(dir string) (*Watcher, error) {
+	return nil, errors.New("Watching directories is only supported on linux")
+}